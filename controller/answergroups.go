@@ -0,0 +1,91 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"quizdrum/model"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// answerGroup is a set of answers that are the same once normalized, so that the
+// quizmaster can grade them all at once.
+type answerGroup struct {
+	// GroupID is the lowest answer ID in the group. It stays the same as more answers
+	// come in, so the scores being entered survive a refresh.
+	GroupID           int64
+	AnswerDisplayText string
+	Count             int
+	// AnswerIDs is the comma separated IDs of the answers in the group.
+	AnswerIDs          string
+	SolverProfileNames []string
+	// Scored is set if every answer in the group has the same points, which are in PointsAwarded.
+	Scored              bool
+	PointsAwarded       int64
+	CustomPointsAwarded bool
+	Match               string
+	UploadURL           string
+	Presets             []int64
+	MinPoints           *int64
+	MaxPoints           *int64
+}
+
+// getAnswerGroups groups the answers to the question, biggest group first. Text answers
+// are grouped by their normalized form, and others by their printable form.
+func getAnswerGroups(qz *model.Quiz, qn *model.Question, answers []*model.Answer) []*answerGroup {
+	groups := make([]*answerGroup, 0)
+	byKey := make(map[string]*answerGroup)
+	ids := make(map[*answerGroup][]string)
+	for _, ans := range answers {
+		ad := getAnswerDisplay(ans, qz, qn)
+		key := tallyKey(ad.AnswerDisplayText)
+		if ans.GetType() == model.AnswerType_TEXT_ANSWER {
+			key = normalizeAnswerText(ans.GetAnsText(), qn.GetAnswerKey().GetNormalization())
+		}
+		g, ok := byKey[key]
+		if !ok {
+			g = &answerGroup{
+				GroupID:           ad.AnswerID,
+				AnswerDisplayText: strings.TrimSpace(ad.AnswerDisplayText),
+				Scored:            true,
+				PointsAwarded:     ad.PointsAwarded,
+				Match:             ad.Match,
+				UploadURL:         ad.UploadURL,
+				Presets:           ad.Presets,
+				MinPoints:         ad.MinPoints,
+				MaxPoints:         ad.MaxPoints,
+			}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.Count++
+		g.GroupID = min(g.GroupID, ad.AnswerID)
+		g.SolverProfileNames = append(g.SolverProfileNames, ad.SolverProfileName)
+		ids[g] = append(ids[g], strconv.FormatInt(ad.AnswerID, 10))
+		if ans.PointsAwarded == nil || ans.GetPointsAwarded() != g.PointsAwarded {
+			g.Scored = false
+		}
+	}
+	for _, g := range groups {
+		g.AnswerIDs = strings.Join(ids[g], ",")
+		g.CustomPointsAwarded = g.Scored && !slices.Contains(g.Presets, g.PointsAwarded)
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].Count > groups[j].Count
+	})
+	return groups
+}
@@ -0,0 +1,119 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetAnswerGroups(t *testing.T) {
+	qz := &model.Quiz{}
+	qn := &model.Question{AnswerKey: &model.AnswerKey{}}
+	text := func(id int64, s string, points *int64) *model.Answer {
+		return &model.Answer{Id: proto.Int64(id), Type: model.AnswerType_TEXT_ANSWER.Enum(),
+			AnsText: proto.String(s), PointsAwarded: points}
+	}
+	groups := getAnswerGroups(qz, qn, []*model.Answer{
+		text(3, "Rome", nil),
+		text(4, "paris ", proto.Int64(10)),
+		text(2, "Paris", proto.Int64(10)),
+		text(5, "PARIS", proto.Int64(0)),
+	})
+	if len(groups) != 2 {
+		t.Fatalf("want 2 groups, got %v", groups)
+	}
+	paris := groups[0]
+	if paris.Count != 3 || paris.GroupID != 2 || paris.AnswerIDs != "4,2,5" || paris.Scored {
+		t.Errorf("want the three unevenly scored Paris answers first, got %+v", paris)
+	}
+	if rome := groups[1]; rome.Count != 1 || rome.GroupID != 3 || rome.Scored {
+		t.Errorf("want the unscored Rome answer on its own, got %+v", rome)
+	}
+}
+
+func TestGetIDToScoreMapFromPostFormGroups(t *testing.T) {
+	got, err := getIDToScoreMapFromPostForm(url.Values{
+		"grp-2-score": {"custom"}, "grp-2-custom-score": {"7"}, "grp-2-answers": {"2,4,5"},
+		"ans-3-score": {"0"},
+	}, &model.PointScale{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int64]int64{2: 7, 4: 7, 5: 7, 3: 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if _, err := getIDToScoreMapFromPostForm(url.Values{"grp-2-score": {"5"}, "grp-2-answers": {"2,x"}},
+		&model.PointScale{}); err == nil {
+		t.Errorf("want an error for a bad answer ID in the group")
+	}
+}
+
+func TestGradeAnswerGroup(t *testing.T) {
+	// The templates are found from the top of the repository.
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text")
+	var ids []string
+	for i, ans := range []string{"Paris", "paris", "Rome"} {
+		ppCookie := callController("POST", "/api/common/guest-login", "", nil, nil, c.HandleGuestLogin).cookie
+		callController("POST", "/api/participant/set-profile",
+			fmt.Sprintf("quiz-id=%v&profile-name=P%v", tq.qzid, i), ppCookie, nil, c.SetProfile)
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=%v", tq.qzid, tq.qnid, ans), ppCookie, nil, c.SubmitAnswer)
+		if r.statuscode != 200 {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		ids = append(ids, r.resptext)
+	}
+
+	vars := map[string]string{"questionid": tq.qnid}
+	r := callController("GET", fmt.Sprintf("/api/quizmaster/question/%v/getallanswers?grouped=1", tq.qnid), "",
+		tq.qmCookie, vars, c.GetAllAnswersForQuestion)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to get the groups. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if !strings.Contains(r.resptext, "2 answers") || !strings.Contains(r.resptext, "1 answer") {
+		t.Errorf("want a group of 2 and a group of 1, got %v", r.resptext)
+	}
+
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("grp-%v-score=10&grp-%v-answers=%v,%v", ids[0], ids[0], ids[0], ids[1]), tq.qmCookie, vars, c.SaveScores)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to save the group scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	qn, _ := strconv.ParseInt(tq.qnid, 10, 64)
+	answers, err := p.GetAllAnswersToQuestionID(uint(qn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, ans := range answers {
+		want := int64(10)
+		if ans.GetAnsText() == "Rome" {
+			want = 0
+		}
+		if ans.GetPointsAwarded() != want {
+			t.Errorf("want %v points for %v, got %v", want, ans.GetAnsText(), ans.GetPointsAwarded())
+		}
+	}
+}
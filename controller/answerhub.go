@@ -0,0 +1,108 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"encoding/json"
+	"log"
+	"sync"
+)
+
+var (
+	// answerFeedBacklog is how many messages a quizmaster connection may fall behind
+	// before it is disconnected.
+	answerFeedBacklog = 64
+)
+
+// answerFeedMessage is sent in both directions over the quizmaster answer feed.
+// Type is one of:
+//   - "answer": server to client, a new or updated answer. HTML is the rendered answer card.
+//   - "scores": client to server to save Scores for QuestionID, and server to client when they are saved.
+//   - "saved": server to client, acknowledges the scores sent by this client.
+//   - "error": server to client, Error describes what went wrong.
+type answerFeedMessage struct {
+	Type       string
+	QuestionID int64           `json:",omitempty"`
+	Answer     *answerDisplay  `json:",omitempty"`
+	HTML       string          `json:"Html,omitempty"`
+	Scores     map[int64]int64 `json:",omitempty"`
+	Error      string          `json:",omitempty"`
+}
+
+// answerHub fans out answer feed messages to the quizmasters connected to a quiz.
+// The zero value is ready to use.
+type answerHub struct {
+	mu   sync.Mutex
+	subs map[int64]map[chan []byte]struct{}
+}
+
+// subscribe registers a quizmaster connection on the given quiz. The returned channel
+// is closed if the connection falls too far behind. The returned function must be
+// called to release the connection.
+func (h *answerHub) subscribe(qzid int64) (<-chan []byte, func()) {
+	ch := make(chan []byte, answerFeedBacklog)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[int64]map[chan []byte]struct{})
+	}
+	if h.subs[qzid] == nil {
+		h.subs[qzid] = make(map[chan []byte]struct{})
+	}
+	h.subs[qzid][ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subs[qzid][ch]; ok {
+			h.remove(qzid, ch)
+		}
+	}
+}
+
+// publish sends the message to every quizmaster connected to the given quiz.
+func (h *answerHub) publish(qzid int64, m *answerFeedMessage) {
+	b, err := json.Marshal(m)
+	if err != nil {
+		log.Printf("could not marshal answer feed message: %v", err)
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[qzid] {
+		select {
+		case ch <- b:
+		default:
+			// Unlike quiz status, answers cannot be skipped, so a connection
+			// that is this far behind has to reload from scratch.
+			h.remove(qzid, ch)
+		}
+	}
+}
+
+// hasListeners tells whether any quizmaster is connected to the given quiz.
+func (h *answerHub) hasListeners(qzid int64) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs[qzid]) > 0
+}
+
+// remove must be called with h.mu held.
+func (h *answerHub) remove(qzid int64, ch chan []byte) {
+	delete(h.subs[qzid], ch)
+	close(ch)
+	if len(h.subs[qzid]) == 0 {
+		delete(h.subs, qzid)
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+)
+
+func TestAnswerHubDropsSlowConnections(t *testing.T) {
+	var h answerHub
+	ch, unsubscribe := h.subscribe(3)
+	for i := 0; i <= answerFeedBacklog; i++ {
+		h.publish(3, &answerFeedMessage{Type: "answer"})
+	}
+	n := 0
+	for range ch {
+		n++
+	}
+	if n != answerFeedBacklog {
+		t.Errorf("want %v messages before the connection was dropped, got %v", answerFeedBacklog, n)
+	}
+	// Releasing a dropped connection must not close the channel again.
+	unsubscribe()
+}
+
+func TestQmAnswerFeed(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text")
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Party", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+
+	r := mux.NewRouter()
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/answerfeed", c.QmAnswerFeed)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	feedURL := fmt.Sprintf("ws%v/api/quizmaster/quiz/%v/answerfeed", strings.TrimPrefix(srv.URL, "http"), tq.qzid)
+
+	// A participant cannot listen in on the answers.
+	if _, resp, err := websocket.DefaultDialer.Dial(feedURL, http.Header{"Cookie": {tq.ppCookie.String()}}); err == nil {
+		t.Errorf("participant could open the answer feed")
+	} else if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("want HTTP 401 for a participant, got %v", resp)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(feedURL, http.Header{"Cookie": {tq.qmCookie.String()}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	read := func() *answerFeedMessage {
+		var m answerFeedMessage
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		if err := conn.ReadJSON(&m); err != nil {
+			t.Fatal(err)
+		}
+		return &m
+	}
+
+	ansid := callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Paris", tq.qzid, tq.qnid), tq.ppCookie, nil, c.SubmitAnswer).resptext
+	m := read()
+	if m.Type != "answer" || m.Answer == nil || m.Answer.AnswerDisplayText != "Paris" ||
+		m.Answer.SolverProfileName != "Party" || fmt.Sprint(m.Answer.AnswerID) != ansid {
+		t.Errorf("unexpected answer message: %+v", m)
+	}
+
+	aid, _ := strconv.ParseInt(ansid, 10, 64)
+	qn, _ := strconv.ParseInt(tq.qnid, 10, 64)
+	if err := conn.WriteJSON(&answerFeedMessage{Type: "scores", QuestionID: qn, Scores: map[int64]int64{aid: 7}}); err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]*answerFeedMessage{}
+	for i := 0; i < 2; i++ {
+		m := read()
+		got[m.Type] = m
+	}
+	if m := got["scores"]; m == nil || m.Scores[aid] != 7 {
+		t.Errorf("scores were not broadcast: %+v", got)
+	}
+	if got["saved"] == nil {
+		t.Errorf("scores were not acknowledged: %+v", got)
+	}
+	ans, err := p.GetAnswerByID(uint(aid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.GetPointsAwarded() != 7 {
+		t.Errorf("want 7 points stored, got %v", ans.GetPointsAwarded())
+	}
+
+	// The presenter view only listens.
+	if conn, _, err = websocket.DefaultDialer.Dial(feedURL+"?readonly=true", http.Header{"Cookie": {tq.qmCookie.String()}}); err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if err := conn.WriteJSON(&answerFeedMessage{Type: "scores", QuestionID: qn, Scores: map[int64]int64{aid: 3}}); err != nil {
+		t.Fatal(err)
+	}
+	if m := read(); m.Type != "error" {
+		t.Errorf("want an error for scores sent to a read-only feed, got %+v", m)
+	}
+	if ans, err = p.GetAnswerByID(uint(aid)); err != nil {
+		t.Fatal(err)
+	}
+	if ans.GetPointsAwarded() != 7 {
+		t.Errorf("want the 7 points kept, got %v", ans.GetPointsAwarded())
+	}
+}
@@ -0,0 +1,265 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"quizdrum/model"
+	"quizdrum/view"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// bankRow is a question in the bank, as shown to its owner.
+type bankRow struct {
+	Q *model.BankQuestion
+	// UsedIn is the quizzes that the question was saved from or added to.
+	UsedIn []bankQuiz
+	// InQuiz is set if the question was already used in the quiz that questions are being added to.
+	InQuiz bool
+}
+
+// bankQuiz names a quiz that a bank question was used in.
+type bankQuiz struct {
+	ID    int64
+	Title string
+}
+
+// getBankTags splits the comma-separated tags. Tags that differ only in case are the same tag,
+// and the first spelling is kept.
+func getBankTags(s string) []string {
+	tags := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// hasBankTag returns true if the bank question has the tag, in any case.
+func hasBankTag(bq *model.BankQuestion, tag string) bool {
+	return slices.ContainsFunc(bq.GetTags(), func(t string) bool { return strings.EqualFold(t, tag) })
+}
+
+// getAllBankTags returns every tag in the bank, sorted, in the first spelling found.
+func getAllBankTags(bqs []*model.BankQuestion) []string {
+	var tags []string
+	for _, bq := range bqs {
+		for _, tag := range bq.GetTags() {
+			if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	slices.SortFunc(tags, func(a, b string) int { return strings.Compare(strings.ToLower(a), strings.ToLower(b)) })
+	return tags
+}
+
+// getBankRows names the quizzes that the bank questions were used in. If tag is set, only
+// the questions with that tag are included. If qzid is set, the questions already used in
+// that quiz are marked.
+func (c *Controller) getBankRows(bqs []*model.BankQuestion, tag string, qzid int64) []bankRow {
+	titles := make(map[int64]string)
+	rows := make([]bankRow, 0, len(bqs))
+	for _, bq := range bqs {
+		if tag != "" && !hasBankTag(bq, tag) {
+			continue
+		}
+		row := bankRow{Q: bq}
+		for _, id := range bq.GetUsedInQuizIds() {
+			title, ok := titles[id]
+			if !ok {
+				// A quiz that has been deleted since is still listed, so that its crowd is remembered.
+				title = fmt.Sprintf("Quiz %v", id)
+				if qz, err := c.P.GetQuizWithoutQuestions(id); err == nil && qz.GetTitle() != "" {
+					title = qz.GetTitle()
+				}
+				titles[id] = title
+			}
+			row.UsedIn = append(row.UsedIn, bankQuiz{ID: id, Title: title})
+			if id == qzid {
+				row.InQuiz = true
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// getOwnBankQuestion returns the bank question named in the URL, or an error if the user does not own it.
+func (c *Controller) getOwnBankQuestion(r *http.Request, u *model.User) (*model.BankQuestion, error) {
+	bankID, err := strconv.Atoi(mux.Vars(r)["bankid"])
+	if err != nil {
+		return nil, err
+	}
+	bq, err := c.P.GetBankQuestion(uint(bankID))
+	if err != nil {
+		return nil, err
+	}
+	if bq.GetOwnerId() != u.GetId() {
+		return nil, errors.New("the question is in the bank of another user")
+	}
+	return bq, nil
+}
+
+// QmBank shows the quizmaster their question bank. The form value quiz names a quiz that the
+// questions can be copied or linked into, and tag shows only the questions with that tag.
+func (c *Controller) QmBank(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.RedirToLoginIfError(err, w, r) {
+		return
+	}
+	var qz *model.Quiz
+	if s := r.FormValue("quiz"); s != "" {
+		qzid, err := strconv.Atoi(s)
+		if view.Should500(err, w, "could not parse quiz id") {
+			return
+		}
+		if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w,
+			"You do not have access to edit this quiz. Please <a href='/logout'>Logout</a>"+
+				" and then log in again with an account that has access.") {
+			return
+		}
+		qz, err = c.P.GetQuizWithoutQuestions(int64(qzid))
+		if view.Should500(err, w, "could not fetch quiz") {
+			return
+		}
+	}
+	bqs, err := c.P.GetBankQuestionsForOwner(u.GetId())
+	if view.Should500(err, w, "could not fetch the question bank") {
+		return
+	}
+	tag := strings.TrimSpace(r.FormValue("tag"))
+
+	type bank struct {
+		U *model.User
+		// Quiz is the quiz that questions are being added to, if any.
+		Quiz *model.Quiz
+		Tag  string
+		Tags []string
+		Rows []bankRow
+	}
+	c.V.RenderTemplate(w, "qm_bank.html", bank{
+		U:    u,
+		Quiz: qz,
+		Tag:  tag,
+		Tags: getAllBankTags(bqs),
+		Rows: c.getBankRows(bqs, tag, qz.GetId()),
+	})
+}
+
+// SaveQuestionToBank is the API handler that copies the question qn-id into the question bank
+// of the user, with the comma-separated tags in bank-tags.
+func (c *Controller) SaveQuestionToBank(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	r.ParseForm()
+	qnid, err := strconv.Atoi(r.PostForm.Get("qn-id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not parse the question id: %v", err)
+		return
+	}
+	qn, err := c.P.GetQuestionByID(uint(qnid))
+	if view.Should500(err, w, "could not find the question") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(qn.GetQuizId(), u), w, "no write privileges") {
+		return
+	}
+	bankID, err := c.P.SaveQuestionToBank(uint(qnid), u.GetId(), getBankTags(r.PostForm.Get("bank-tags")))
+	if view.Should500(err, w, "could not save the question to the bank") {
+		return
+	}
+	view.WriteJSONString(w, fmt.Sprint(bankID))
+}
+
+// AddBankQuestionToQuiz is the API handler that adds a bank question to the end of the quiz
+// quiz-id. If link is true, later changes to the question are shared with the bank and with
+// every other question linked to it. Otherwise the quiz gets a copy.
+func (c *Controller) AddBankQuestionToQuiz(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	bq, err := c.getOwnBankQuestion(r, u)
+	if view.UnauthIfError(err, w, "no access to this bank question") {
+		return
+	}
+	r.ParseForm()
+	qzid, err := strconv.Atoi(r.PostForm.Get("quiz-id"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not parse the quiz id: %v", err)
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	link := r.PostForm.Get("link") == "true"
+	qnid, err := c.P.AddBankQuestionToQuiz(uint(bq.GetId()), int64(qzid), link)
+	if view.Should500(err, w, "could not add the question to the quiz") {
+		return
+	}
+	view.WriteJSONString(w, fmt.Sprint(qnid))
+}
+
+// UpdateBankQuestionTags is the API handler that replaces the tags of a bank question with
+// the comma-separated tags in bank-tags.
+func (c *Controller) UpdateBankQuestionTags(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	bq, err := c.getOwnBankQuestion(r, u)
+	if view.UnauthIfError(err, w, "no access to this bank question") {
+		return
+	}
+	r.ParseForm()
+	if view.Should500(c.P.SaveBankQuestionTags(uint(bq.GetId()), getBankTags(r.PostForm.Get("bank-tags"))),
+		w, "could not save the tags") {
+		return
+	}
+	fmt.Fprintln(w, "Saved")
+}
+
+// DeleteBankQuestion is the API handler that removes a question from the bank. The quiz
+// questions linked to it keep their content, but are no longer changed together.
+func (c *Controller) DeleteBankQuestion(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	bq, err := c.getOwnBankQuestion(r, u)
+	if view.UnauthIfError(err, w, "no access to this bank question") {
+		return
+	}
+	if view.Should500(c.P.DeleteBankQuestion(uint(bq.GetId())), w, "could not delete the bank question") {
+		return
+	}
+	fmt.Fprintln(w, "deleted")
+}
@@ -0,0 +1,199 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetBankTags(t *testing.T) {
+	for _, tc := range []struct {
+		in   string
+		want []string
+	}{
+		{"", []string{}},
+		{"geography", []string{"geography"}},
+		{" Easy, geography ,easy,, ", []string{"Easy", "geography"}},
+	} {
+		if got := getBankTags(tc.in); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("getBankTags(%q): want %v, got %v", tc.in, tc.want, got)
+		}
+	}
+}
+
+func TestQuestionBank(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	spring := newTestQuiz(t, c, "qn-title=Capital+of+France&qn-body=Which+city&qn-type=text")
+	qmCookie, otherCookie, qnid := spring.qmCookie, spring.ppCookie, spring.qnid
+	// The second quiz is made by the same quizmaster.
+	autumn := callController("POST", "/api/quizmaster/newquiz", "quiz-title=Autumn&quiz-descr=Bank",
+		qmCookie, nil, c.NewQuiz).resptext
+
+	r := callController("POST", "/api/quizmaster/bank/save", "qn-id="+qnid+"&bank-tags=Geography,+easy",
+		otherCookie, nil, c.SaveQuestionToBank)
+	if r.statuscode != http.StatusUnauthorized {
+		t.Errorf("want: HTTP 401 for saving a question of another quizmaster. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("POST", "/api/quizmaster/bank/save", "qn-id="+qnid+"&bank-tags=Geography,+easy",
+		qmCookie, nil, c.SaveQuestionToBank)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not save to the bank: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	bankID := strings.Trim(r.resptext, "\"\n")
+	bankVars := map[string]string{"bankid": bankID}
+
+	addToQuiz := func(cookie *http.Cookie, qzid string, link bool) savedHTTPResponse {
+		return callController("POST", fmt.Sprintf("/api/quizmaster/bank/%v/addtoquiz", bankID),
+			fmt.Sprintf("quiz-id=%v&link=%v", qzid, link), cookie, bankVars, c.AddBankQuestionToQuiz)
+	}
+	if r := addToQuiz(otherCookie, autumn, false); r.statuscode != http.StatusUnauthorized {
+		t.Errorf("want: HTTP 401 for using the bank of another quizmaster. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = addToQuiz(qmCookie, autumn, true)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not link the question: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	linkID := strings.Trim(r.resptext, "\"\n")
+
+	r = callController("GET", "/quizmaster/bank?quiz="+autumn+"&tag=geography", "", qmCookie, nil, c.QmBank)
+	for _, want := range []string{"Capital of France", `value="Geography, easy"`, ">Test</a>, <a",
+		">Autumn</a>", "Already used in this quiz", "Link into Quiz"} {
+		if !strings.Contains(r.resptext, want) {
+			t.Errorf("want %q on the bank page:\n%v", want, r.resptext)
+		}
+	}
+	r = callController("GET", "/quizmaster/bank?tag=history", "", qmCookie, nil, c.QmBank)
+	if strings.Contains(r.resptext, "Capital of France") || !strings.Contains(r.resptext, "No question in your bank has the tag history") {
+		t.Errorf("the tag should filter the bank:\n%v", r.resptext)
+	}
+	r = callController("GET", "/quizmaster/bank", "", otherCookie, nil, c.QmBank)
+	if strings.Contains(r.resptext, "Capital of France") {
+		t.Errorf("another quizmaster should not see the bank:\n%v", r.resptext)
+	}
+
+	// Editing the linked question changes the bank, but not the question it was saved from.
+	updateLinked := func(title string, extra string) {
+		t.Helper()
+		r := callController("PUT", fmt.Sprintf("/api/quizmaster/question/%v/update", linkID),
+			fmt.Sprintf("quiz-id=%v&qn-id=%v&qn-title=%v&qn-body=Which+city&qn-type=text%v", autumn, linkID, title, extra),
+			qmCookie, map[string]string{"questionid": linkID}, c.UpdateQuestion)
+		if r.statuscode != http.StatusOK {
+			t.Fatalf("could not update the question: HTTP %v. %v", r.statuscode, r.resptext)
+		}
+	}
+	checkTitles := func(bankTitle, linkedTitle string) {
+		t.Helper()
+		id, _ := strconv.Atoi(bankID)
+		bq, err := p.GetBankQuestion(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ = strconv.Atoi(linkID)
+		linked, err := p.GetQuestionByID(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, _ = strconv.Atoi(qnid)
+		original, err := p.GetQuestionByID(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bq.GetQuestion().GetTitle() != bankTitle || linked.GetTitle() != linkedTitle ||
+			original.GetTitle() != "Capital of France" {
+			t.Errorf("want titles %q, %q and %q. got %q, %q and %q", bankTitle, linkedTitle, "Capital of France",
+				bq.GetQuestion().GetTitle(), linked.GetTitle(), original.GetTitle())
+		}
+	}
+	updateLinked("Capital+of+Spain", "")
+	checkTitles("Capital of Spain", "Capital of Spain")
+	updateLinked("Capital+of+Italy", "&qn-unlink=true")
+	checkTitles("Capital of Spain", "Capital of Italy")
+
+	r = callController("PUT", fmt.Sprintf("/api/quizmaster/bank/%v/tags", bankID), "bank-tags=history",
+		qmCookie, bankVars, c.UpdateBankQuestionTags)
+	if r.statuscode != http.StatusOK {
+		t.Errorf("could not save the tags: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("DELETE", fmt.Sprintf("/api/quizmaster/bank/%v/delete", bankID), "",
+		otherCookie, bankVars, c.DeleteBankQuestion)
+	if r.statuscode != http.StatusUnauthorized {
+		t.Errorf("want: HTTP 401 for deleting from the bank of another quizmaster. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("DELETE", fmt.Sprintf("/api/quizmaster/bank/%v/delete", bankID), "",
+		qmCookie, bankVars, c.DeleteBankQuestion)
+	if r.statuscode != http.StatusOK {
+		t.Errorf("could not delete from the bank: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("GET", "/quizmaster/bank", "", qmCookie, nil, c.QmBank)
+	if !strings.Contains(r.resptext, "Your question bank is empty") {
+		t.Errorf("the bank should be empty:\n%v", r.resptext)
+	}
+}
+
+func TestSaveLinkedQuestionOfAnotherUser(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Capital+of+France&qn-body=Which+city&qn-type=text")
+	autumn := callController("POST", "/api/quizmaster/newquiz", "quiz-title=Autumn&quiz-descr=Bank",
+		tq.qmCookie, nil, c.NewQuiz).resptext
+	r := callController("POST", "/api/quizmaster/bank/save", "qn-id="+tq.qnid, tq.qmCookie, nil, c.SaveQuestionToBank)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not save to the bank: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	bankID := strings.Trim(r.resptext, "\"\n")
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/bank/%v/addtoquiz", bankID),
+		fmt.Sprintf("quiz-id=%v&link=true", autumn), tq.qmCookie, map[string]string{"bankid": bankID}, c.AddBankQuestionToQuiz)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not link the question: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	linkID, _ := strconv.Atoi(strings.Trim(r.resptext, "\"\n"))
+
+	// The other user can neither write to the bank of the quizmaster nor to their quizzes.
+	other, err := p.GetUserFromCookie(tq.ppCookie.Value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qn, err := p.GetQuestionByID(uint(linkID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	qn.Title = proto.String("Capital of Spain")
+	if err := c.saveQuestion(qn, other); err != nil {
+		t.Fatal(err)
+	}
+	id, _ := strconv.Atoi(bankID)
+	bq, err := p.GetBankQuestion(uint(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bq.GetQuestion().GetTitle() != "Capital of France" {
+		t.Errorf("want the bank question unchanged, got %v", bq.GetQuestion().GetTitle())
+	}
+	if qn, err = p.GetQuestionByID(uint(linkID)); err != nil {
+		t.Fatal(err)
+	}
+	if qn.GetTitle() != "Capital of Spain" || qn.GetLinkedToBank() {
+		t.Errorf("want the change saved as an unlinked copy, got %v", qn)
+	}
+}
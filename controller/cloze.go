@@ -0,0 +1,184 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"quizdrum/model"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// clozeBlank marks a blank in the body of a cloze question.
+	clozeBlank = regexp.MustCompile(`_{3,}`)
+)
+
+// clozePart is a piece of the body of a cloze question, either text or a blank.
+type clozePart struct {
+	Text  string
+	Blank bool
+	// Value is what the participant wrote in the blank so far.
+	Value string
+}
+
+// countBlanks returns the number of blanks in the body of a cloze question.
+func countBlanks(qn *model.Question) int {
+	return len(clozeBlank.FindAllStringIndex(qn.GetHtmlBody(), -1))
+}
+
+// getClozeParts splits the body of a cloze question into text and blanks, with the
+// answers the participant has already given.
+func getClozeParts(qn *model.Question, ans *model.Answer) []clozePart {
+	if qn.GetType() != model.AnswerType_CLOZE_ANSWER {
+		return nil
+	}
+	var parts []clozePart
+	body := qn.GetHtmlBody()
+	last := 0
+	for i, loc := range clozeBlank.FindAllStringIndex(body, -1) {
+		if loc[0] > last {
+			parts = append(parts, clozePart{Text: body[last:loc[0]]})
+		}
+		p := clozePart{Blank: true}
+		if i < len(ans.GetAnsBlanks()) {
+			p.Value = ans.GetAnsBlanks()[i]
+		}
+		parts = append(parts, p)
+		last = loc[1]
+	}
+	if last < len(body) {
+		parts = append(parts, clozePart{Text: body[last:]})
+	}
+	return parts
+}
+
+// getBlankPoints returns how many points each blank of a cloze question is worth.
+func getBlankPoints(qn *model.Question) []int64 {
+	blanks := qn.GetAnswerKey().GetBlanks()
+	if len(blanks) == 0 {
+		return nil
+	}
+	total := int64(defaultQuestionPoints)
+	if qn.Points != nil {
+		total = qn.GetPoints()
+	}
+	share := int64(math.Round(float64(total) / float64(len(blanks))))
+	points := make([]int64, 0, len(blanks))
+	for _, b := range blanks {
+		if b.Points != nil {
+			points = append(points, b.GetPoints())
+		} else {
+			points = append(points, share)
+		}
+	}
+	return points
+}
+
+// matchClozeAnswer matches what was written in each blank with the key to that blank,
+// the same way text answers are matched.
+func matchClozeAnswer(key *model.AnswerKey, answers []string) []model.TextMatch {
+	matches := make([]model.TextMatch, 0, len(key.GetBlanks()))
+	for i, b := range key.GetBlanks() {
+		if i >= len(answers) || len(b.GetAcceptedTexts()) == 0 {
+			matches = append(matches, model.TextMatch_NO_MATCH)
+			continue
+		}
+		blankKey := &model.AnswerKey{
+			AcceptedTexts:   b.GetAcceptedTexts(),
+			Normalization:   key.GetNormalization(),
+			MaxEditDistance: key.MaxEditDistance,
+		}
+		matches = append(matches, matchTextAnswer(blankKey, answers[i]))
+	}
+	return matches
+}
+
+// getClozeMatch sums up how the blanks matched, as described for TextMatch.
+func getClozeMatch(matches []model.TextMatch) model.TextMatch {
+	exact, none := 0, 0
+	for _, m := range matches {
+		switch m {
+		case model.TextMatch_FUZZY_MATCH:
+			return model.TextMatch_FUZZY_MATCH
+		case model.TextMatch_EXACT_MATCH:
+			exact++
+		case model.TextMatch_NO_MATCH:
+			none++
+		}
+	}
+	switch len(matches) {
+	case exact:
+		return model.TextMatch_EXACT_MATCH
+	case none:
+		return model.TextMatch_NO_MATCH
+	}
+	return model.TextMatch_UNKNOWN_TEXT_MATCH
+}
+
+// gradeClozeAnswer adds up the points of the blanks that were filled in correctly.
+func gradeClozeAnswer(qz *model.Quiz, qn *model.Question, ans *model.Answer) (int64, bool) {
+	points := getBlankPoints(qn)
+	if len(points) == 0 {
+		return 0, false
+	}
+	var total int64
+	for i, m := range matchClozeAnswer(qn.GetAnswerKey(), ans.GetAnsBlanks()) {
+		if m != model.TextMatch_NO_MATCH {
+			total += points[i]
+		}
+	}
+	if total <= 0 {
+		return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+	}
+	return total, true
+}
+
+// setClozeAnswerKeyFromFormValues reads the key to each blank from the rows of qn-blank-accept,
+// the accepted answers separated by "|", and qn-blank-points, which may be left empty.
+func setClozeAnswerKeyFromFormValues(p url.Values, qn *model.Question, key *model.AnswerKey) error {
+	accepts, points := p["qn-blank-accept"], p["qn-blank-points"]
+	if len(points) != len(accepts) {
+		return fmt.Errorf("every blank needs its points, even if empty")
+	}
+	for i, a := range accepts {
+		var b model.BlankKey
+		for _, t := range strings.Split(a, "|") {
+			if t = strings.TrimSpace(t); t != "" {
+				b.AcceptedTexts = append(b.AcceptedTexts, t)
+			}
+		}
+		if len(b.AcceptedTexts) == 0 {
+			return fmt.Errorf("blank %v needs at least one accepted answer", i+1)
+		}
+		if points[i] != "" {
+			v, err := strconv.ParseInt(points[i], 10, 64)
+			if err != nil {
+				return err
+			}
+			b.Points = proto.Int64(v)
+		}
+		key.Blanks = append(key.Blanks, &b)
+	}
+	if n := countBlanks(qn); len(key.Blanks) != n {
+		return fmt.Errorf("the question has %v blanks but the answer key has %v", n, len(key.Blanks))
+	}
+	return nil
+}
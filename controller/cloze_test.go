@@ -0,0 +1,108 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetClozeParts(t *testing.T) {
+	qn := &model.Question{Type: model.AnswerType_CLOZE_ANSWER.Enum(),
+		HtmlBody: proto.String("The ___ and the _____.")}
+	if n := countBlanks(qn); n != 2 {
+		t.Errorf("want 2 blanks, got %v", n)
+	}
+	got := getClozeParts(qn, &model.Answer{AnsBlanks: []string{"Owl"}})
+	want := []clozePart{{Text: "The "}, {Blank: true, Value: "Owl"}, {Text: " and the "}, {Blank: true}, {Text: "."}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestGradeClozeAnswer(t *testing.T) {
+	qn, err := GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"The ___ and the ___"}, "qn-type": {"cloze"},
+		"qn-blank-accept": {"Owl", "Pussycat | Pussy-cat"}, "qn-blank-points": {"", "6"},
+		"qn-points": {"8"}, "qn-key-max-edits": {"1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getBlankPoints(qn); !reflect.DeepEqual(got, []int64{4, 6}) {
+		t.Errorf("want an equal share of 4 for the first blank and 6 for the second, got %v", got)
+	}
+	tests := []struct {
+		blanks []string
+		points int64
+		match  model.TextMatch
+	}{
+		{[]string{"owl", "pussycat"}, 10, model.TextMatch_EXACT_MATCH},
+		{[]string{"Owl", "Dog"}, 4, model.TextMatch_UNKNOWN_TEXT_MATCH},
+		{[]string{"Owls", "Pussy-cat"}, 10, model.TextMatch_FUZZY_MATCH},
+		{[]string{"Cat", "Dog"}, 0, model.TextMatch_NO_MATCH},
+		{[]string{"Owl"}, 4, model.TextMatch_UNKNOWN_TEXT_MATCH},
+	}
+	for _, tc := range tests {
+		ans := &model.Answer{Type: qn.GetType().Enum(), AnsBlanks: tc.blanks}
+		applyAnswerKey(&model.Quiz{}, qn, ans)
+		if ans.GetPointsAwarded() != tc.points || ans.GetTextMatch() != tc.match {
+			t.Errorf("%q: got %v points and %v, want %v and %v", tc.blanks, ans.GetPointsAwarded(), ans.GetTextMatch(),
+				tc.points, tc.match)
+		}
+	}
+
+	for _, v := range []url.Values{
+		{"qn-body": {"No blanks here"}},
+		{"qn-body": {"The ___ and the ___"}, "qn-blank-accept": {"Owl"}, "qn-blank-points": {""}},
+		{"qn-body": {"The ___"}, "qn-blank-accept": {" | "}, "qn-blank-points": {""}},
+	} {
+		v.Set("quiz-id", "1")
+		v.Set("qn-title", "Q")
+		v.Set("qn-type", "cloze")
+		if _, err := GetQuestionFromPostBody(v); err == nil {
+			t.Errorf("want an error for %v", v)
+		}
+	}
+
+	ans, err := GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-blank": {"Owl", " "}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getPrintableStringFromAnswer(ans); got != "Owl / ___" {
+		t.Errorf("got %q, want the blanks", got)
+	}
+}
+
+func TestLiveClozeQuestion(t *testing.T) {
+	t.Chdir("..")
+	c, _ := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=The+___+and+the+Pussycat&qn-type=cloze&qn-blank-accept=Owl&qn-blank-points=")
+	r := callController("GET", fmt.Sprintf("/participant/quiz/%v/live", tq.qzid), "", tq.ppCookie,
+		map[string]string{"quizid": tq.qzid}, c.RenderLiveQuiz)
+	if r.statuscode != 200 {
+		t.Fatalf("want: HTTP 200 for the live page. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if strings.Contains(r.resptext, "The ___") || strings.Count(r.resptext, "and the Pussycat") != 1 {
+		t.Errorf("want the body shown once, as the answer form:\n%v", r.resptext)
+	}
+}
@@ -15,8 +15,10 @@
 package controller
 
 import (
+	"log"
 	"quizdrum/model"
 	"quizdrum/view"
+	"time"
 )
 
 // Controller holds state that is used across all handlers
@@ -25,4 +27,97 @@ type Controller struct {
 	P *model.Persistence
 	// V contains methods to display UI to the user.
 	V *view.View
+
+	// status notifies participants listening on a live quiz of changes.
+	status statusHub
+	// answers pushes answers to the quizmasters running a live quiz.
+	answers answerHub
+	// deadlines closes responses when the countdown on a live question runs out.
+	deadlines deadlineTimers
+	// starts activates the first question of quizzes that are scheduled to start automatically.
+	starts deadlineTimers
+}
+
+// Initialize hooks the live updates up to the changes stored by P.
+// It must be called before the handlers are served.
+func (c *Controller) Initialize() error {
+	c.P.Events.Subscribe(0, c.onQuizEvent)
+	// Pick up the countdowns and scheduled starts from when the server last stopped.
+	qzs, err := c.P.GetAllQuizzes()
+	if err != nil {
+		return err
+	}
+	for _, qz := range qzs {
+		c.watchResponseDeadline(qz)
+		c.watchScheduledStart(qz)
+	}
+	return nil
+}
+
+func (c *Controller) onQuizEvent(e model.Event) {
+	switch e := e.(type) {
+	case *model.QuizSaved:
+		c.status.publish(e.QuizID(), getQuizStatus(e.Quiz, time.Now()))
+		c.watchResponseDeadline(e.Quiz)
+		c.watchScheduledStart(e.Quiz)
+	case *model.ParticipantJoined:
+		c.publishLobby(e.QuizID())
+	case *model.AcceptingResponsesChanged:
+		if !e.AcceptingResponses {
+			c.gradeLiveQuestion(e.QuizID())
+		}
+	case *model.AnswerSubmitted:
+		c.publishAnswer(e.QuizID(), e.Answer)
+	case *model.ScoresSaved:
+		byQuestion := make(map[int64]map[int64]int64)
+		for _, ans := range e.Answers {
+			if byQuestion[ans.GetQuestionId()] == nil {
+				byQuestion[ans.GetQuestionId()] = make(map[int64]int64)
+			}
+			byQuestion[ans.GetQuestionId()][ans.GetId()] = ans.GetPointsAwarded()
+		}
+		for qnid, scores := range byQuestion {
+			c.answers.publish(e.QuizID(), &answerFeedMessage{Type: "scores", QuestionID: qnid, Scores: scores})
+		}
+	}
+}
+
+// publishAnswer tells the quizmasters on the answer feed about a new or updated answer.
+func (c *Controller) publishAnswer(qzid int64, ans *model.Answer) {
+	if !c.answers.hasListeners(qzid) {
+		return
+	}
+	// The participant profiles are needed to show who answered, and the question
+	// for the point scale.
+	qz, err := c.P.GetQuizWithoutQuestions(qzid)
+	if err != nil {
+		log.Printf("could not load the quiz for the answer feed: %v", err)
+		return
+	}
+	qn, err := c.P.GetQuestionByID(uint(ans.GetQuestionId()))
+	if err != nil {
+		log.Printf("could not load the question for the answer feed: %v", err)
+		return
+	}
+	ad := getAnswerDisplay(ans, qz, qn)
+	m := &answerFeedMessage{Type: "answer", QuestionID: ans.GetQuestionId(), Answer: ad}
+	h, err := c.V.RenderTemplateToString("qm_answer_card", ad)
+	if err != nil {
+		// The client can still show the answer after a refresh.
+		log.Printf("could not render the answer card: %v", err)
+	}
+	m.HTML = h
+	c.answers.publish(qzid, m)
+}
+
+// publishLobby tells the participants waiting in the lobby who else has joined.
+func (c *Controller) publishLobby(qzid int64) {
+	qz, err := c.P.GetQuizWithoutQuestions(qzid)
+	if err != nil {
+		log.Printf("could not load the quiz for the lobby: %v", err)
+		return
+	}
+	if inLobby(qz) {
+		c.status.publish(qzid, getQuizStatus(qz, time.Now()))
+	}
 }
@@ -60,6 +60,79 @@ func callController(verb string, path string, body string, cookie *http.Cookie,
 	return r
 }
 
+// newTestController returns a controller with an empty database in memory. Tests that
+// render templates call t.Chdir("..") first, so that the templates are found.
+func newTestController(t *testing.T) (*Controller, *model.Persistence) {
+	t.Helper()
+	var p model.Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	var v view.View
+	v.Initialize()
+	c := Controller{
+		P: &p, V: &v,
+	}
+	if err := c.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	return &c, &p
+}
+
+// testQuiz is a quiz made through the API, for the tests that play it.
+type testQuiz struct {
+	// qmCookie logs in the quizmaster who made the quiz.
+	qmCookie *http.Cookie
+	// ppCookie logs in a participant, who has not joined the quiz yet.
+	ppCookie *http.Cookie
+	qzid     string
+	// qnid is the question that was made live along with the quiz, if any.
+	qnid string
+}
+
+// newTestQuiz logs in a quizmaster and a participant, and has the quizmaster make a quiz.
+// If qnForm is set, the quiz gets the question in the form and the question is made live.
+func newTestQuiz(t *testing.T, c *Controller, qnForm string) testQuiz {
+	t.Helper()
+	tq := testQuiz{
+		qmCookie: callController("POST", "/api/common/guest-login", "", nil, nil, c.HandleGuestLogin).cookie,
+		ppCookie: callController("POST", "/api/common/guest-login", "", nil, nil, c.HandleGuestLogin).cookie,
+	}
+	r := callController("POST", "/api/quizmaster/newquiz", "quiz-title=Test&quiz-descr=Test",
+		tq.qmCookie, nil, c.NewQuiz)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to create quiz. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	tq.qzid = r.resptext
+	if qnForm != "" {
+		tq.qnid = tq.addQuestion(t, c, qnForm)
+		tq.setActive(t, c, tq.qnid)
+	}
+	return tq
+}
+
+// addQuestion has the quizmaster add the question in the form, which does not need the
+// quiz-id field, and returns the ID of the question.
+func (tq testQuiz) addQuestion(t *testing.T, c *Controller, qnForm string) string {
+	t.Helper()
+	r := callController("POST", "/api/quizmaster/question/new", fmt.Sprintf("quiz-id=%v&%v", tq.qzid, qnForm),
+		tq.qmCookie, nil, c.NewQuestion)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to create question. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	return r.resptext
+}
+
+// setActive has the quizmaster make the question live.
+func (tq testQuiz) setActive(t *testing.T, c *Controller, qnid string) {
+	t.Helper()
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/setactive/%v", tq.qzid, qnid), "",
+		tq.qmCookie, map[string]string{"quizid": tq.qzid, "questionid": qnid}, c.SetActiveQuestionID)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to set the live question. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+}
+
 func TestUserJourney(t *testing.T) {
 	var p model.Persistence
 	p.Initialize(":memory:", "oauth_client_fake_id")
@@ -0,0 +1,118 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"log"
+	"quizdrum/model"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// deadlineGracePeriod allows for answers that were in flight when the countdown ran out.
+	deadlineGracePeriod = 1 * time.Second
+)
+
+// deadlineTimers keeps at most one pending timer per quiz. The zero value is ready to use.
+type deadlineTimers struct {
+	mu     sync.Mutex
+	timers map[int64]*time.Timer
+}
+
+// schedule calls f at the given time, replacing any timer pending for the quiz.
+func (d *deadlineTimers) schedule(qzid int64, at time.Time, f func()) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.timers == nil {
+		d.timers = make(map[int64]*time.Timer)
+	}
+	if t, ok := d.timers[qzid]; ok {
+		t.Stop()
+	}
+	d.timers[qzid] = time.AfterFunc(time.Until(at), f)
+}
+
+// cancel stops the timer pending for the quiz, if any.
+func (d *deadlineTimers) cancel(qzid int64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if t, ok := d.timers[qzid]; ok {
+		t.Stop()
+		delete(d.timers, qzid)
+	}
+}
+
+// activateQuestion makes qn the live question of the quiz, open for responses from now on.
+func activateQuestion(qz *model.Quiz, qn *model.Question, now time.Time) {
+	qz.LiveQuestionId = proto.Int64(qn.GetId())
+	qz.LiveRoundId = nil
+	qz.AcceptingResponses = proto.Bool(true)
+	qz.AnswerRevealed = proto.Bool(false)
+	setResponseDeadline(qz, qn, now)
+	if qz.QuestionActivatedMs == nil {
+		qz.QuestionActivatedMs = make(map[int64]int64)
+	}
+	qz.QuestionActivatedMs[qn.GetId()] = now.UnixMilli()
+}
+
+// setResponseDeadline starts the countdown for the question if it has a time limit,
+// or clears the countdown if it does not.
+func setResponseDeadline(qz *model.Quiz, qn *model.Question, now time.Time) {
+	if qn.GetTimeLimitS() <= 0 {
+		qz.ResponseDeadlineMs = nil
+		return
+	}
+	deadline := now.Add(time.Duration(qn.GetTimeLimitS()) * time.Second)
+	qz.ResponseDeadlineMs = proto.Int64(deadline.UnixMilli())
+}
+
+// getResponseDeadline returns the time at which the live question stops accepting responses.
+// The second return value is false if there is no time limit.
+func getResponseDeadline(qz *model.Quiz) (time.Time, bool) {
+	if qz.GetResponseDeadlineMs() == 0 {
+		return time.Time{}, false
+	}
+	return time.UnixMilli(qz.GetResponseDeadlineMs()), true
+}
+
+// isAcceptingResponses tells if the quiz is accepting responses at the given time.
+// This does not wait for the timer to close responses, since that could be late.
+func isAcceptingResponses(qz *model.Quiz, now time.Time) bool {
+	if !qz.GetAcceptingResponses() || qz.GetAnswerRevealed() {
+		return false
+	}
+	if deadline, ok := getResponseDeadline(qz); ok && now.After(deadline) {
+		return false
+	}
+	return true
+}
+
+// watchResponseDeadline makes sure the quiz stops accepting responses when its countdown runs out.
+func (c *Controller) watchResponseDeadline(qz *model.Quiz) {
+	deadline, ok := getResponseDeadline(qz)
+	if !ok || !qz.GetAcceptingResponses() {
+		c.deadlines.cancel(qz.GetId())
+		return
+	}
+	qzid, deadlineMs := qz.GetId(), qz.GetResponseDeadlineMs()
+	c.deadlines.schedule(qzid, deadline, func() {
+		if err := c.P.CloseResponsesAtDeadline(qzid, deadlineMs); err != nil {
+			log.Printf("could not close responses for quiz %v at the deadline: %v", qzid, err)
+		}
+	})
+}
@@ -0,0 +1,94 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"quizdrum/model"
+	"strconv"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetQuizStatusWithDeadline(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	qz := &model.Quiz{LiveQuestionId: proto.Int64(3), AcceptingResponses: proto.Bool(true)}
+	setResponseDeadline(qz, &model.Question{TimeLimitS: proto.Int64(20)}, now)
+
+	testCases := []struct {
+		at   time.Time
+		want quizStatus
+	}{
+		{at: now, want: quizStatus{QuestionID: 3, AcceptingResponses: true, RemainingMs: 20000}},
+		{at: now.Add(19500 * time.Millisecond), want: quizStatus{QuestionID: 3, AcceptingResponses: true, RemainingMs: 500}},
+		{at: now.Add(21 * time.Second), want: quizStatus{QuestionID: 3, AcceptingResponses: false}},
+	}
+	for _, tc := range testCases {
+		if got := getQuizStatus(qz, tc.at); got != tc.want {
+			t.Errorf("at %v: got %+v, want %+v", tc.at.Sub(now), got, tc.want)
+		}
+	}
+
+	setResponseDeadline(qz, &model.Question{}, now)
+	if got, want := getQuizStatus(qz, now.Add(time.Hour)), (quizStatus{QuestionID: 3, AcceptingResponses: true}); got != want {
+		t.Errorf("without a time limit: got %+v, want %+v", got, want)
+	}
+}
+
+func TestTimedQuestion(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Quick&qn-type=text&qn-time-limit=30")
+
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuizWithoutQuestions(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline, ok := getResponseDeadline(qz)
+	if !ok {
+		t.Fatalf("activating a timed question did not set a deadline")
+	}
+	if left := time.Until(deadline); left < 29*time.Second || left > 30*time.Second {
+		t.Errorf("want about 30s left to respond, got %v", left)
+	}
+
+	// Pretend the countdown ran out a while ago, before the timer could close responses.
+	qz.ResponseDeadlineMs = proto.Int64(time.Now().Add(-time.Minute).UnixMilli())
+	if err := p.SaveQuiz(qz); err != nil {
+		t.Fatal(err)
+	}
+	r := callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Late", tq.qzid, tq.qnid), tq.ppCookie, nil, c.SubmitAnswer)
+	if r.statuscode != 409 {
+		t.Errorf("want: HTTP 409 for a late answer. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	// The timer set up for the new deadline closes responses right away.
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		qz, err := p.GetQuizWithoutQuestions(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !qz.GetAcceptingResponses() {
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("responses were not closed after the deadline")
+		}
+	}
+}
@@ -0,0 +1,334 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"log"
+	"math"
+	"quizdrum/model"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// defaultQuestionPoints is what a correct answer gets if the question does not say.
+	defaultQuestionPoints = 10
+)
+
+// getQuestionPoints returns how many points a correct answer to the question gets.
+func getQuestionPoints(qn *model.Question) int64 {
+	if qn.GetType() == model.AnswerType_CLOZE_ANSWER && len(qn.GetAnswerKey().GetBlanks()) > 0 {
+		var total int64
+		for _, pts := range getBlankPoints(qn) {
+			total += pts
+		}
+		return total
+	}
+	if qn.Points == nil {
+		return defaultQuestionPoints
+	}
+	return qn.GetPoints()
+}
+
+// gradeAnswer checks the answer against the answer key of the question, and returns the points
+// it should get. Wrong answers get the wrong answer points of the point scale. The second return
+// value is false if the answer key cannot grade this answer, in which case it is up to the quizmaster.
+func gradeAnswer(qz *model.Quiz, qn *model.Question, ans *model.Answer) (int64, bool) {
+	key := qn.GetAnswerKey()
+	if key == nil || ans.GetType() != qn.GetType() || qn.GetTieBreaker() {
+		return 0, false
+	}
+	var correct bool
+	switch qn.GetType() {
+	case model.AnswerType_TEXT_ANSWER:
+		if len(key.GetAcceptedTexts()) == 0 && len(key.GetAliases()) == 0 {
+			return 0, false
+		}
+		correct = matchTextAnswer(key, ans.GetAnsText()) != model.TextMatch_NO_MATCH
+	case model.AnswerType_INT64_ANSWER, model.AnswerType_FLOAT_ANSWER:
+		v, ok := getNumericAnswer(ans)
+		if !ok {
+			return 0, false
+		}
+		return gradeNumericAnswer(qz, qn, v)
+	case model.AnswerType_BOOL_ANSWER:
+		if key.CorrectBool == nil {
+			return 0, false
+		}
+		correct = ans.GetAnsBool() == key.GetCorrectBool()
+	case model.AnswerType_MULTIPLE_CHOICE_ANSWER:
+		if key.CorrectChoiceIndex == nil {
+			return 0, false
+		}
+		correct = ans.GetAnsChoiceIndex() == key.GetCorrectChoiceIndex()
+	case model.AnswerType_MULTI_SELECT_ANSWER:
+		return gradeMultiSelectAnswer(qz, qn, ans)
+	case model.AnswerType_ORDERING_ANSWER:
+		return gradeOrderingAnswer(qz, qn, ans)
+	case model.AnswerType_MATCHING_ANSWER:
+		return gradeMatchingAnswer(qz, qn, ans)
+	case model.AnswerType_CLOZE_ANSWER:
+		return gradeClozeAnswer(qz, qn, ans)
+	default:
+		return 0, false
+	}
+	if correct {
+		return getQuestionPoints(qn), true
+	}
+	return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+}
+
+// getNumericAnswer returns the value of an INT64_ANSWER or FLOAT_ANSWER.
+func getNumericAnswer(ans *model.Answer) (float64, bool) {
+	switch ans.GetType() {
+	case model.AnswerType_INT64_ANSWER:
+		return float64(ans.GetAnsInt()), true
+	case model.AnswerType_FLOAT_ANSWER:
+		// Answers are stored as float32. Going through the shortest decimal form keeps an
+		// answer of 0.1 equal to a key of 0.1.
+		v, err := strconv.ParseFloat(strconv.FormatFloat(float64(ans.GetAnsFloat()), 'g', -1, 32), 64)
+		return v, err == nil
+	}
+	return 0, false
+}
+
+// getCorrectRange returns the lowest and highest correct answers to a numeric question.
+// The last return value is false if the answer key does not say.
+func getCorrectRange(qn *model.Question) (float64, float64, bool) {
+	key := qn.GetAnswerKey()
+	if key == nil {
+		return 0, 0, false
+	}
+	if key.RangeMin != nil && key.RangeMax != nil {
+		return key.GetRangeMin(), key.GetRangeMax(), true
+	}
+	switch {
+	case qn.GetType() == model.AnswerType_INT64_ANSWER && key.CorrectInt != nil:
+		return float64(key.GetCorrectInt()), float64(key.GetCorrectInt()), true
+	case qn.GetType() == model.AnswerType_FLOAT_ANSWER && key.CorrectFloat != nil:
+		return key.GetCorrectFloat(), key.GetCorrectFloat(), true
+	}
+	return 0, 0, false
+}
+
+// gradeNumericAnswer gives full points to answers in the correct range, and a share of the
+// points to answers in the tolerance bands around it.
+func gradeNumericAnswer(qz *model.Quiz, qn *model.Question, v float64) (int64, bool) {
+	lo, hi, ok := getCorrectRange(qn)
+	if !ok {
+		return 0, false
+	}
+	var distance, nearest float64
+	switch {
+	case v < lo:
+		distance, nearest = lo-v, lo
+	case v > hi:
+		distance, nearest = v-hi, hi
+	default:
+		return getQuestionPoints(qn), true
+	}
+	var percent int64
+	for _, b := range qn.GetAnswerKey().GetToleranceBands() {
+		inBand := (b.Absolute != nil && distance <= b.GetAbsolute()) ||
+			(b.RelativePercent != nil && distance <= math.Abs(nearest)*b.GetRelativePercent()/100)
+		if inBand && b.GetPointsPercent() > percent {
+			percent = b.GetPointsPercent()
+		}
+	}
+	if percent == 0 {
+		return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+	}
+	return int64(math.Round(float64(getQuestionPoints(qn)*percent) / 100)), true
+}
+
+// gradeMultiSelectAnswer compares the chosen options with the correct ones, and gives a share
+// of the points for partly right answers if the answer key allows it.
+func gradeMultiSelectAnswer(qz *model.Quiz, qn *model.Question, ans *model.Answer) (int64, bool) {
+	correct := qn.GetAnswerKey().GetCorrectChoiceIndices()
+	if len(correct) == 0 {
+		return 0, false
+	}
+	var right, wrong int64
+	for _, i := range ans.GetAnsChoiceIndices() {
+		if slices.Contains(correct, i) {
+			right++
+		} else {
+			wrong++
+		}
+	}
+	var share int64
+	switch qn.GetAnswerKey().GetMultiSelectGrading() {
+	case model.MultiSelectGrading_PER_CORRECT_OPTION:
+		share = right
+	case model.MultiSelectGrading_PENALTY_PER_WRONG_OPTION:
+		share = right - wrong
+	default:
+		if right == int64(len(correct)) && wrong == 0 {
+			share = right
+		}
+	}
+	if share <= 0 {
+		return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+	}
+	return int64(math.Round(float64(getQuestionPoints(qn)*share) / float64(len(correct)))), true
+}
+
+// applyAnswerKey sets the points of the answer, and for text answers how it matched, from the
+// answer key of the question. The points are kept within the bounds of the point scale, like
+// the scores the quizmaster gives. It reports whether the answer changed.
+func applyAnswerKey(qz *model.Quiz, qn *model.Question, ans *model.Answer) bool {
+	pts, ok := gradeAnswer(qz, qn, ans)
+	if !ok {
+		return false
+	}
+	pts = clampScore(getPointScale(qz, qn), pts)
+	changed := ans.PointsAwarded == nil || pts != ans.GetPointsAwarded()
+	ans.PointsAwarded = proto.Int64(pts)
+	var match model.TextMatch
+	switch qn.GetType() {
+	case model.AnswerType_TEXT_ANSWER:
+		match = matchTextAnswer(qn.GetAnswerKey(), ans.GetAnsText())
+	case model.AnswerType_CLOZE_ANSWER:
+		match = getClozeMatch(matchClozeAnswer(qn.GetAnswerKey(), ans.GetAnsBlanks()))
+	default:
+		return changed
+	}
+	changed = changed || match != ans.GetTextMatch()
+	ans.TextMatch = match.Enum()
+	return changed
+}
+
+// matchTextAnswer compares a text answer with the accepted texts and aliases in the answer key.
+// A fuzzy match is one within the allowed number of edits of an accepted text.
+func matchTextAnswer(key *model.AnswerKey, text string) model.TextMatch {
+	n := key.GetNormalization()
+	ans := normalizeAnswerText(text, n)
+	for _, texts := range [][]string{key.GetAcceptedTexts(), key.GetAliases()} {
+		for _, t := range texts {
+			if normalizeAnswerText(t, n) == ans {
+				return model.TextMatch_EXACT_MATCH
+			}
+		}
+	}
+	// Very short answers would otherwise be a few edits away from everything.
+	if max := int(key.GetMaxEditDistance()); max > 0 && utf8.RuneCountInString(ans) > max {
+		for _, t := range key.GetAcceptedTexts() {
+			if editDistance(normalizeAnswerText(t, n), ans) <= max {
+				return model.TextMatch_FUZZY_MATCH
+			}
+		}
+	}
+	return model.TextMatch_NO_MATCH
+}
+
+// normalizeAnswerText removes the differences between text answers that n says to ignore.
+// A nil n ignores case and spacing.
+func normalizeAnswerText(s string, n *model.TextNormalization) string {
+	if n.GetIgnoreDiacritics() {
+		t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+		if r, _, err := transform.String(t, s); err == nil {
+			s = r
+		}
+	}
+	if n.GetIgnorePunctuation() {
+		s = strings.Map(func(r rune) rune {
+			if unicode.IsPunct(r) {
+				return -1
+			}
+			return r
+		}, s)
+	}
+	if n.GetIgnoreWhitespace() {
+		s = strings.Join(strings.Fields(s), " ")
+	}
+	if n.GetIgnoreArticles() {
+		if i := strings.IndexFunc(s, unicode.IsSpace); i > 0 {
+			switch strings.ToLower(s[:i]) {
+			case "the", "a", "an":
+				s = strings.TrimLeftFunc(s[i:], unicode.IsSpace)
+			}
+		}
+	}
+	if n.GetIgnoreCase() {
+		s = strings.ToLower(s)
+	}
+	return s
+}
+
+// editDistance is the number of letters that have to be inserted, deleted or replaced to turn a into b.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// gradeLiveQuestion grades the answers to the live question of the quiz once it stops
+// accepting responses. This picks up changes to the answer key made during the question.
+func (c *Controller) gradeLiveQuestion(qzid int64) {
+	qz, err := c.P.GetQuizWithoutQuestions(qzid)
+	if err != nil {
+		log.Printf("could not load quiz %v for grading: %v", qzid, err)
+		return
+	}
+	if qz.GetLiveQuestionId() == 0 {
+		return
+	}
+	if err := c.gradeQuestion(qz, qz.GetLiveQuestionId()); err != nil {
+		log.Printf("could not grade the answers to question %v: %v", qz.GetLiveQuestionId(), err)
+	}
+}
+
+// gradeQuestion grades all the answers to the question, except those the quizmaster has scored by hand.
+func (c *Controller) gradeQuestion(qz *model.Quiz, qnid int64) error {
+	qn, err := c.P.GetQuestionByID(uint(qnid))
+	if err != nil {
+		return err
+	}
+	if qn.GetAnswerKey() == nil {
+		return nil
+	}
+	sansa, err := c.P.GetAllAnswersToQuestionID(uint(qnid))
+	if err != nil {
+		return err
+	}
+	answersToUpdate, _ := regradeAnswers(qz, qn, sansa, false)
+	if len(answersToUpdate) == 0 {
+		return nil
+	}
+	return c.P.SaveMultipleAnswers(answersToUpdate, nil)
+}
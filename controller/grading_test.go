@@ -0,0 +1,350 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGradeAnswer(t *testing.T) {
+	text := &model.Question{
+		Type:      model.AnswerType_TEXT_ANSWER.Enum(),
+		AnswerKey: &model.AnswerKey{AcceptedTexts: []string{"New  York", "NYC"}},
+	}
+	mcq := &model.Question{
+		Type:      model.AnswerType_MULTIPLE_CHOICE_ANSWER.Enum(),
+		Points:    proto.Int64(3),
+		AnswerKey: &model.AnswerKey{CorrectChoiceIndex: proto.Int64(1)},
+	}
+	num := &model.Question{
+		Type:      model.AnswerType_INT64_ANSWER.Enum(),
+		AnswerKey: &model.AnswerKey{CorrectInt: proto.Int64(1969)},
+	}
+	tf := &model.Question{
+		Type:      model.AnswerType_BOOL_ANSWER.Enum(),
+		AnswerKey: &model.AnswerKey{CorrectBool: proto.Bool(false)},
+	}
+	tests := []struct {
+		qn     *model.Question
+		ans    *model.Answer
+		points int64
+		ok     bool
+	}{
+		{text, &model.Answer{Type: text.GetType().Enum(), AnsText: proto.String(" new york ")}, 10, true},
+		{text, &model.Answer{Type: text.GetType().Enum(), AnsText: proto.String("nyc")}, 10, true},
+		{text, &model.Answer{Type: text.GetType().Enum(), AnsText: proto.String("Boston")}, 0, true},
+		{mcq, &model.Answer{Type: mcq.GetType().Enum(), AnsChoiceIndex: proto.Int64(1)}, 3, true},
+		{mcq, &model.Answer{Type: mcq.GetType().Enum(), AnsChoiceIndex: proto.Int64(0)}, 0, true},
+		{num, &model.Answer{Type: num.GetType().Enum(), AnsInt: proto.Int64(1969)}, 10, true},
+		{tf, &model.Answer{Type: tf.GetType().Enum(), AnsBool: proto.Bool(false)}, 10, true},
+		{tf, &model.Answer{Type: tf.GetType().Enum(), AnsBool: proto.Bool(true)}, 0, true},
+		// Answers the key cannot grade are left to the quizmaster.
+		{num, &model.Answer{Type: text.GetType().Enum(), AnsText: proto.String("1969")}, 0, false},
+		{&model.Question{Type: text.GetType().Enum()},
+			&model.Answer{Type: text.GetType().Enum(), AnsText: proto.String("NYC")}, 0, false},
+	}
+	for _, tc := range tests {
+		points, ok := gradeAnswer(&model.Quiz{}, tc.qn, tc.ans)
+		if points != tc.points || ok != tc.ok {
+			t.Errorf("gradeAnswer(%v, %v) = %v, %v; want %v, %v", tc.qn, tc.ans, points, ok, tc.points, tc.ok)
+		}
+	}
+}
+
+func TestGradeMultiSelectAnswer(t *testing.T) {
+	msq := func(g model.MultiSelectGrading) *model.Question {
+		return &model.Question{
+			Type:   model.AnswerType_MULTI_SELECT_ANSWER.Enum(),
+			Points: proto.Int64(9),
+			AnswerKey: &model.AnswerKey{
+				CorrectChoiceIndices: []int64{0, 2, 3},
+				MultiSelectGrading:   g.Enum(),
+			},
+		}
+	}
+	all := msq(model.MultiSelectGrading_ALL_OR_NOTHING)
+	each := msq(model.MultiSelectGrading_PER_CORRECT_OPTION)
+	penalty := msq(model.MultiSelectGrading_PENALTY_PER_WRONG_OPTION)
+	tests := []struct {
+		qn     *model.Question
+		picked []int64
+		points int64
+	}{
+		{all, []int64{0, 2, 3}, 9},
+		{all, []int64{0, 2}, 0},
+		{all, []int64{0, 1, 2, 3}, 0},
+		{each, []int64{0, 2}, 6},
+		{each, []int64{0, 1, 2, 3}, 9},
+		{each, nil, 0},
+		{penalty, []int64{0, 1, 2}, 3},
+		{penalty, []int64{0, 1, 2, 3}, 6},
+		// Penalties do not go below the points for a wrong answer.
+		{penalty, []int64{1, 4, 5}, 0},
+	}
+	for _, tc := range tests {
+		ans := &model.Answer{Type: tc.qn.GetType().Enum(), AnsChoiceIndices: tc.picked}
+		if points, ok := gradeAnswer(&model.Quiz{}, tc.qn, ans); points != tc.points || !ok {
+			t.Errorf("%v with %v: got %v, %v; want %v", tc.qn.GetAnswerKey().GetMultiSelectGrading(), tc.picked,
+				points, ok, tc.points)
+		}
+	}
+}
+
+func TestMultiSelectFromPostBody(t *testing.T) {
+	qn, err := GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Pick all"}, "qn-type": {"msq"},
+		"mcq-opt": {"A", "B", "C"}, "qn-key-choices": {"3, 1,3"}, "qn-key-msq-grading": {"penalty"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(qn.GetAnswerKey().GetCorrectChoiceIndices(), []int64{0, 2}) ||
+		qn.GetAnswerKey().GetMultiSelectGrading() != model.MultiSelectGrading_PENALTY_PER_WRONG_OPTION {
+		t.Errorf("want options 1 and 3 with a penalty, got %v", qn)
+	}
+	for _, v := range []string{"4", "1, x"} {
+		if _, err := GetQuestionFromPostBody(url.Values{
+			"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Pick all"}, "qn-type": {"msq"},
+			"mcq-opt": {"A", "B", "C"}, "qn-key-choices": {v},
+		}); err == nil {
+			t.Errorf("want an error for correct options %q", v)
+		}
+	}
+
+	ans, err := GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-msq": {"", "2", "0", "2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.GetType() != model.AnswerType_MULTI_SELECT_ANSWER || !reflect.DeepEqual(ans.GetAnsChoiceIndices(), []int64{0, 2}) {
+		t.Errorf("want options 0 and 2 picked, got %v", ans)
+	}
+	ans, err = GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-msq": {""}})
+	if err != nil || ans.GetType() != model.AnswerType_MULTI_SELECT_ANSWER || len(ans.GetAnsChoiceIndices()) != 0 {
+		t.Errorf("want an answer with no options picked, got %v, %v", ans, err)
+	}
+}
+
+func TestGradeNumericAnswer(t *testing.T) {
+	everest := &model.Question{
+		Type: model.AnswerType_INT64_ANSWER.Enum(),
+		AnswerKey: &model.AnswerKey{CorrectInt: proto.Int64(8848), ToleranceBands: []*model.ToleranceBand{
+			{RelativePercent: proto.Float64(1)},
+			{RelativePercent: proto.Float64(5), PointsPercent: proto.Int64(50)},
+		}},
+	}
+	year := &model.Question{
+		Type:   model.AnswerType_FLOAT_ANSWER.Enum(),
+		Points: proto.Int64(4),
+		AnswerKey: &model.AnswerKey{RangeMin: proto.Float64(1900), RangeMax: proto.Float64(1910),
+			ToleranceBands: []*model.ToleranceBand{{Absolute: proto.Float64(2), PointsPercent: proto.Int64(25)}}},
+	}
+	pi := &model.Question{
+		Type:      model.AnswerType_FLOAT_ANSWER.Enum(),
+		AnswerKey: &model.AnswerKey{CorrectFloat: proto.Float64(3.14)},
+	}
+	tests := []struct {
+		qn     *model.Question
+		ans    *model.Answer
+		points int64
+	}{
+		{everest, &model.Answer{Type: everest.GetType().Enum(), AnsInt: proto.Int64(8848)}, 10},
+		{everest, &model.Answer{Type: everest.GetType().Enum(), AnsInt: proto.Int64(8900)}, 10},
+		{everest, &model.Answer{Type: everest.GetType().Enum(), AnsInt: proto.Int64(8500)}, 5},
+		{everest, &model.Answer{Type: everest.GetType().Enum(), AnsInt: proto.Int64(8000)}, 0},
+		{year, &model.Answer{Type: year.GetType().Enum(), AnsFloat: proto.Float32(1905.5)}, 4},
+		{year, &model.Answer{Type: year.GetType().Enum(), AnsFloat: proto.Float32(1912)}, 1},
+		{year, &model.Answer{Type: year.GetType().Enum(), AnsFloat: proto.Float32(1897)}, 0},
+		{pi, &model.Answer{Type: pi.GetType().Enum(), AnsFloat: proto.Float32(3.14)}, 10},
+	}
+	for _, tc := range tests {
+		points, ok := gradeAnswer(&model.Quiz{}, tc.qn, tc.ans)
+		if points != tc.points || !ok {
+			t.Errorf("gradeAnswer(%v, %v) = %v, %v; want %v, true", tc.qn, tc.ans, points, ok, tc.points)
+		}
+	}
+}
+
+func TestMatchTextAnswer(t *testing.T) {
+	all := &model.TextNormalization{
+		IgnorePunctuation: proto.Bool(true),
+		IgnoreDiacritics:  proto.Bool(true),
+		IgnoreArticles:    proto.Bool(true),
+	}
+	beatles := &model.AnswerKey{AcceptedTexts: []string{"The Beatles"}, Aliases: []string{"Fab Four"},
+		Normalization: all, MaxEditDistance: proto.Int64(2)}
+	tests := []struct {
+		key  *model.AnswerKey
+		text string
+		want model.TextMatch
+	}{
+		{beatles, "beatles", model.TextMatch_EXACT_MATCH},
+		{beatles, "  THE   Beatles!", model.TextMatch_EXACT_MATCH},
+		{beatles, "Beetles", model.TextMatch_FUZZY_MATCH},
+		{beatles, "the fab four.", model.TextMatch_EXACT_MATCH},
+		// Aliases are not matched fuzzily.
+		{beatles, "Fab Fours", model.TextMatch_NO_MATCH},
+		{beatles, "Rolling Stones", model.TextMatch_NO_MATCH},
+		// Answers no longer than the allowed edits are not matched fuzzily.
+		{beatles, "Be", model.TextMatch_NO_MATCH},
+		{&model.AnswerKey{AcceptedTexts: []string{"Motörhead"}, Normalization: all}, "motorhead", model.TextMatch_EXACT_MATCH},
+		// Without a normalization, only case and spacing are ignored.
+		{&model.AnswerKey{AcceptedTexts: []string{"Motörhead"}}, " MOTÖRHEAD", model.TextMatch_EXACT_MATCH},
+		{&model.AnswerKey{AcceptedTexts: []string{"Motörhead"}}, "Motorhead", model.TextMatch_NO_MATCH},
+		{&model.AnswerKey{AcceptedTexts: []string{"Motörhead"}, Normalization: &model.TextNormalization{
+			IgnoreCase: proto.Bool(false)}}, "motörhead", model.TextMatch_NO_MATCH},
+	}
+	for _, tc := range tests {
+		if got := matchTextAnswer(tc.key, tc.text); got != tc.want {
+			t.Errorf("matchTextAnswer(%v, %q) = %v, want %v", tc.key, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestSetAnswerKeyFromFormValues(t *testing.T) {
+	qn, err := GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Pick"}, "qn-type": {"mcq"},
+		"mcq-opt": {"A", "B", "C"}, "qn-points": {"5"}, "qn-key-choice": {"3"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qn.GetPoints() != 5 || qn.GetAnswerKey().GetCorrectChoiceIndex() != 2 {
+		t.Errorf("want 5 points for the third option, got %v", qn)
+	}
+	_, err = GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Pick"}, "qn-type": {"mcq"},
+		"mcq-opt": {"A", "B"}, "qn-key-choice": {"3"},
+	})
+	if err == nil {
+		t.Errorf("want an error for a correct option that does not exist")
+	}
+	qn, err = GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Say"}, "qn-type": {"text"},
+		"qn-key-text": {"Paris\r\n\r\n Paris, France \n"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := qn.GetAnswerKey().GetAcceptedTexts(); len(got) != 2 || got[1] != "Paris, France" {
+		t.Errorf("want two accepted answers, got %q", got)
+	}
+	if n := qn.GetAnswerKey().GetNormalization(); n != nil {
+		t.Errorf("want the default normalization when the form does not send it, got %v", n)
+	}
+	qn, err = GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Say"}, "qn-type": {"text"},
+		"qn-key-aliases": {"Paree"}, "qn-key-max-edits": {"1"}, "qn-text-norm": {"", "diacritics"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := qn.GetAnswerKey()
+	if key.GetAliases()[0] != "Paree" || key.GetMaxEditDistance() != 1 {
+		t.Errorf("want the alias and the allowed edits set, got %v", key)
+	}
+	if n := key.GetNormalization(); n.GetIgnoreCase() || !n.GetIgnoreDiacritics() {
+		t.Errorf("want only accents ignored, got %v", n)
+	}
+
+	qn, err = GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"How high"}, "qn-type": {"float"},
+		"qn-key-float": {"8848.86"}, "qn-band-tol": {"1", "", "50"},
+		"qn-band-unit": {"pct", "pct", "abs"}, "qn-band-points": {"100", "", "50"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key = qn.GetAnswerKey()
+	if b := key.GetToleranceBands(); key.GetCorrectFloat() != 8848.86 || len(b) != 2 ||
+		b[0].GetRelativePercent() != 1 || b[1].GetAbsolute() != 50 || b[1].GetPointsPercent() != 50 {
+		t.Errorf("want the correct answer and two tolerance bands, got %v", key)
+	}
+	for _, v := range []url.Values{
+		{"qn-key-min": {"1910"}, "qn-key-max": {"1900"}},
+		{"qn-key-min": {"1900"}},
+		{"qn-band-tol": {"5"}, "qn-band-unit": {"pct"}, "qn-band-points": {"50"}},
+		{"qn-key-int": {"8848"}, "qn-band-tol": {"5"}, "qn-band-unit": {"pct"}, "qn-band-points": {"150"}},
+	} {
+		v["quiz-id"], v["qn-title"], v["qn-body"], v["qn-type"] = []string{"1"}, []string{"Q"}, []string{"When"}, []string{"int"}
+		if _, err := GetQuestionFromPostBody(v); err == nil {
+			t.Errorf("want an error for the answer key %v", v)
+		}
+	}
+}
+
+func TestAutomaticGrading(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris")
+
+	submit := func(text string) int64 {
+		ppCookie := callController("POST", "/api/common/guest-login", "", nil, nil, c.HandleGuestLogin).cookie
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=%v", tq.qzid, tq.qnid, text), ppCookie, nil, c.SubmitAnswer)
+		if r.statuscode != 200 {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		ansid, err := strconv.ParseInt(r.resptext, 10, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return ansid
+	}
+	points := func() map[int64]int64 {
+		id, _ := strconv.ParseInt(tq.qnid, 10, 64)
+		answers, err := p.GetAllAnswersToQuestionID(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := make(map[int64]int64)
+		for _, ans := range answers {
+			m[ans.GetId()] = ans.GetPointsAwarded()
+		}
+		return m
+	}
+
+	right, rome, roma := submit("paris"), submit("Rome"), submit("Roma")
+	if got := points(); got[right] != 4 || got[rome] != 0 || got[roma] != 0 {
+		t.Errorf("want answers graded on submission, got %v", got)
+	}
+
+	// The quizmaster gives partial credit by hand, and then accepts more answers in the key.
+	vars := map[string]string{"questionid": tq.qnid}
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=2", rome), tq.qmCookie, vars, c.SaveScores)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to save scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("POST", "/api/quizmaster/question/update",
+		fmt.Sprintf("quiz-id=%v&qn-id=%v&qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris%%0ARome%%0ARoma",
+			tq.qzid, tq.qnid), tq.qmCookie, nil, c.UpdateQuestion)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to update the question. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	// Closing the question regrades everything the quizmaster did not score.
+	callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/setacceptingresponses", tq.qzid),
+		"ar=false", tq.qmCookie, map[string]string{"quizid": tq.qzid}, c.SetAcceptingResponses)
+	if got := points(); got[right] != 4 || got[rome] != 2 || got[roma] != 4 {
+		t.Errorf("want the answer scored by hand kept and the others regraded, got %v", got)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"quizdrum/model"
+	"quizdrum/view"
+	"strconv"
+)
+
+// jokerOption is a round the joker can still be played on.
+type jokerOption struct {
+	RoundID int64
+	Title   string
+}
+
+// jokerStatus is what a participant sees of their joker.
+type jokerStatus struct {
+	// RoundID is the round the joker was played on, if any.
+	RoundID int64
+	// PlayedOn is the title of the round the joker was played on, or "" if it has not been played.
+	PlayedOn string
+	// Options are the rounds that none of the questions have been activated in yet.
+	Options []jokerOption
+}
+
+// getJokerStatus tells the participant where their joker is, or where it can still go.
+// It returns nil if the quiz has no jokers.
+func getJokerStatus(qz *model.Quiz, userID int64) *jokerStatus {
+	if !qz.GetJokerEnabled() {
+		return nil
+	}
+	var played int64
+	for _, pp := range qz.GetParticipants() {
+		if pp.GetUserId() == userID {
+			played = pp.GetJokerRoundId()
+		}
+	}
+	js := jokerStatus{RoundID: played}
+	for _, rd := range qz.GetRounds() {
+		if rd.GetId() == played {
+			js.PlayedOn = rd.GetTitle()
+		}
+		if !roundStarted(qz, rd.GetId()) {
+			js.Options = append(js.Options, jokerOption{RoundID: rd.GetId(), Title: rd.GetTitle()})
+		}
+	}
+	return &js
+}
+
+// roundStarted tells if any question of the round has been activated.
+func roundStarted(qz *model.Quiz, rdid int64) bool {
+	for _, qn := range qz.GetQuestions() {
+		if _, ok := qz.GetQuestionActivatedMs()[qn.GetId()]; ok && qn.GetRoundId() == rdid {
+			return true
+		}
+	}
+	return false
+}
+
+// checkJoker returns an error if the joker cannot be played on the round. It has to be
+// played before the first question of the round is activated, so that it is a bet and not
+// a sure thing.
+func checkJoker(qz *model.Quiz, rdid int64) error {
+	if !qz.GetJokerEnabled() {
+		return errors.New("this quiz has no jokers")
+	}
+	for _, rd := range qz.GetRounds() {
+		if rd.GetId() != rdid {
+			continue
+		}
+		if roundStarted(qz, rdid) {
+			return errors.New("the joker has to be played before the round starts")
+		}
+		return nil
+	}
+	return fmt.Errorf("round %v is not in this quiz", rdid)
+}
+
+// PlayJoker doubles the points of the participant in the round rd-id of the quiz quiz-id.
+func (c *Controller) PlayJoker(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	r.ParseForm()
+	qzid, err := strconv.ParseInt(r.PostForm.Get("quiz-id"), 10, 64)
+	if view.Should500(err, w, "could not parse the quiz id") {
+		return
+	}
+	rdid, err := strconv.ParseInt(r.PostForm.Get("rd-id"), 10, 64)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, "choose a round to play the joker on")
+		return
+	}
+	qz, err := c.P.GetQuiz(qzid)
+	if view.Should500(err, w, "could not fetch quiz") {
+		return
+	}
+	if err := checkJoker(qz, rdid); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	}
+	if err := c.P.PlayJoker(qzid, u.GetId(), rdid); errors.Is(err, model.ErrJokerPlayed) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not play the joker") {
+		return
+	}
+	fmt.Fprintln(w, "written")
+}
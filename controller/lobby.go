@@ -0,0 +1,75 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"log"
+	"quizdrum/model"
+	"time"
+)
+
+// lobbyStatus is what participants see while they wait for a scheduled quiz to start.
+type lobbyStatus struct {
+	// StartsInMs is how long until the scheduled start. It is not set once that time has passed.
+	StartsInMs int64 `json:",omitempty"`
+	// Participants are the profile names of everyone who has registered so far.
+	Participants []string
+}
+
+// inLobby tells if participants should wait in the lobby, which is the case for
+// a scheduled quiz until the first question is activated.
+func inLobby(qz *model.Quiz) bool {
+	return qz.GetExpectedStartTime() != 0 && qz.GetLiveQuestionId() == 0
+}
+
+func getLobbyStatus(qz *model.Quiz, now time.Time) *lobbyStatus {
+	ls := &lobbyStatus{Participants: getRegisteredProfileNames(qz)}
+	if left := getExpectedStartTime(qz).Sub(now); left > 0 {
+		ls.StartsInMs = left.Milliseconds()
+	}
+	return ls
+}
+
+func getExpectedStartTime(qz *model.Quiz) time.Time {
+	return time.Unix(qz.GetExpectedStartTime(), 0)
+}
+
+func getRegisteredProfileNames(qz *model.Quiz) []string {
+	names := make([]string, 0, len(qz.GetParticipants()))
+	for _, pp := range qz.GetParticipants() {
+		if pp.GetCompletedRegistration() {
+			names = append(names, pp.GetProfileName())
+		}
+	}
+	return names
+}
+
+// watchScheduledStart activates the first question of the quiz at its expected start time,
+// if the quizmaster asked for the quiz to start automatically.
+func (c *Controller) watchScheduledStart(qz *model.Quiz) {
+	if !qz.GetAutoStart() || !inLobby(qz) {
+		c.starts.cancel(qz.GetId())
+		return
+	}
+	qzid, startTime := qz.GetId(), qz.GetExpectedStartTime()
+	c.starts.schedule(qzid, getExpectedStartTime(qz), func() {
+		err := c.P.StartScheduledQuiz(qzid, startTime, func(qz *model.Quiz, qn *model.Question) {
+			activateQuestion(qz, qn, time.Now())
+		})
+		if err != nil {
+			log.Printf("could not start quiz %v at the scheduled time: %v", qzid, err)
+		}
+	})
+}
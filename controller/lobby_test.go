@@ -0,0 +1,84 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestScheduledStart(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	qnid := tq.addQuestion(t, c, "qn-title=Q&qn-body=First&qn-type=text")
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	vars := map[string]string{"quizid": tq.qzid}
+
+	// Schedule the quiz for an hour from now, without starting it automatically.
+	start := time.Now().Add(time.Hour).Unix()
+	r := callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/updateproperties", tq.qzid),
+		fmt.Sprintf("qz-title=Later&qz-descr=Later&qz-start-time=%v", start), tq.qmCookie, vars, c.UpdateQuizProperties)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to schedule the quiz. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Early", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+
+	qz, err := p.GetQuizWithoutQuestions(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	st := getQuizStatus(qz, time.Now())
+	if st.Lobby == nil {
+		t.Fatalf("want the participants to wait in the lobby, got %+v", st)
+	}
+	if got, want := st.Lobby.Participants, []string{"Early"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("lobby participants: got %v, want %v", got, want)
+	}
+	if st.Lobby.StartsInMs <= 59*60*1000 {
+		t.Errorf("want about an hour until the start, got %vms", st.Lobby.StartsInMs)
+	}
+
+	// Now ask for the quiz to start automatically, at a time that has already passed.
+	r = callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/updateproperties", tq.qzid),
+		fmt.Sprintf("qz-title=Later&qz-descr=Later&qz-start-time=%v&qz-auto-start=true", time.Now().Unix()-1),
+		tq.qmCookie, vars, c.UpdateQuizProperties)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to schedule the quiz. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	for start := time.Now(); ; time.Sleep(10 * time.Millisecond) {
+		qz, err := p.GetQuizWithoutQuestions(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if qz.GetLiveQuestionId() != 0 {
+			if got := fmt.Sprint(qz.GetLiveQuestionId()); got != qnid || !qz.GetAcceptingResponses() {
+				t.Errorf("want question %v to be live and accepting responses, got %v, %v",
+					qnid, got, qz.GetAcceptingResponses())
+			}
+			if getQuizStatus(qz, time.Now()).Lobby != nil {
+				t.Errorf("the lobby is still shown after the quiz started")
+			}
+			break
+		}
+		if time.Since(start) > 5*time.Second {
+			t.Fatalf("the quiz did not start at the scheduled time")
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"quizdrum/model"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxMatchChoices is how many items the second column can have, since they are lettered.
+	maxMatchChoices = 26
+)
+
+var (
+	matchFormName = regexp.MustCompile(`^ans-match-([0-9]+)$`)
+	matchKeyPair  = regexp.MustCompile(`^([0-9]+)\s*-\s*([A-Za-z])$`)
+)
+
+// matchRow is one item of the first column of a matching question, as shown to the participant.
+type matchRow struct {
+	Index int64
+	Body  string
+	// Selected is the item of the second column the participant matched it to, or -1.
+	Selected int64
+}
+
+// getMatchLetter is how the item of the second column at index i is labelled.
+func getMatchLetter(i int64) string {
+	if i < 0 || i >= maxMatchChoices {
+		return strconv.FormatInt(i+1, 10)
+	}
+	return string(rune('A' + i))
+}
+
+// getMatchRows returns the first column of a matching question with the pairs the
+// participant has already made.
+func getMatchRows(qn *model.Question, ans *model.Answer) []matchRow {
+	rows := make([]matchRow, 0, len(qn.GetChoices()))
+	for i, ch := range qn.GetChoices() {
+		rows = append(rows, matchRow{Index: int64(i), Body: ch.GetHtmlBody(), Selected: -1})
+	}
+	for _, pr := range ans.GetAnsPairs() {
+		if pr.GetLeft() >= 0 && pr.GetLeft() < int64(len(rows)) {
+			rows[pr.GetLeft()].Selected = pr.GetRight()
+		}
+	}
+	return rows
+}
+
+// setMatchChoicesFromFormValues reads the second column of a matching question, one item per line.
+func setMatchChoicesFromFormValues(v string, qn *model.Question) error {
+	for _, line := range getLinesFromFormValue(v) {
+		qn.MatchChoices = append(qn.MatchChoices, &model.AnswerChoice{HtmlBody: proto.String(line)})
+	}
+	if len(qn.GetMatchChoices()) < 2 {
+		return fmt.Errorf("A matching question must have at least two items in the second column")
+	}
+	if len(qn.GetMatchChoices()) > maxMatchChoices {
+		return fmt.Errorf("A matching question can have at most %v items in the second column", maxMatchChoices)
+	}
+	return nil
+}
+
+// setMatchingAnswerKeyFromFormValues reads qn-key-pairs, the comma separated correct pairs
+// such as "1-B", of an item number of the first column and a letter of the second.
+func setMatchingAnswerKeyFromFormValues(p url.Values, qn *model.Question, key *model.AnswerKey) error {
+	seen := make(map[int64]bool)
+	for _, v := range strings.Split(p.Get("qn-key-pairs"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		m := matchKeyPair.FindStringSubmatch(v)
+		if m == nil {
+			return fmt.Errorf("a pair must be an item number and a letter, such as 1-B, got %q", v)
+		}
+		left, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return err
+		}
+		left--
+		right := int64(strings.ToUpper(m[2])[0] - 'A')
+		if left < 0 || left >= int64(len(qn.GetChoices())) || right >= int64(len(qn.GetMatchChoices())) {
+			return fmt.Errorf("the pair %v is not between the items of the two columns", v)
+		}
+		if seen[left] {
+			return fmt.Errorf("item %v is in more than one pair", left+1)
+		}
+		seen[left] = true
+		key.CorrectPairs = append(key.CorrectPairs, &model.MatchPair{Left: proto.Int64(left), Right: proto.Int64(right)})
+	}
+	sort.Slice(key.CorrectPairs, func(i, j int) bool {
+		return key.CorrectPairs[i].GetLeft() < key.CorrectPairs[j].GetLeft()
+	})
+	return nil
+}
+
+// getMatchPairsFromPostForm reads the ans-match-<item> fields, each holding the item of the
+// second column that the item of the first column was matched to, or nothing.
+func getMatchPairsFromPostForm(p url.Values) ([]*model.MatchPair, error) {
+	var pairs []*model.MatchPair
+	for k, v := range p {
+		m := matchFormName.FindStringSubmatch(k)
+		if m == nil || len(v) == 0 || v[0] == "" {
+			continue
+		}
+		left, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		right, err := strconv.ParseInt(v[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		pairs = append(pairs, &model.MatchPair{Left: proto.Int64(left), Right: proto.Int64(right)})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i].GetLeft() < pairs[j].GetLeft()
+	})
+	return pairs, nil
+}
+
+// gradeMatchingAnswer gives each correct pair an equal share of the points.
+func gradeMatchingAnswer(qz *model.Quiz, qn *model.Question, ans *model.Answer) (int64, bool) {
+	correct := qn.GetAnswerKey().GetCorrectPairs()
+	if len(correct) == 0 {
+		return 0, false
+	}
+	want := make(map[int64]int64)
+	for _, pr := range correct {
+		want[pr.GetLeft()] = pr.GetRight()
+	}
+	var right int64
+	for _, pr := range ans.GetAnsPairs() {
+		if r, ok := want[pr.GetLeft()]; ok && r == pr.GetRight() {
+			right++
+			// An item counts once, however many times it was sent.
+			delete(want, pr.GetLeft())
+		}
+	}
+	if right == 0 {
+		return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+	}
+	return int64(math.Round(float64(getQuestionPoints(qn)*right) / float64(len(correct)))), true
+}
+
+// getMatchPairsLabel spells out the pairs of the answer with the text of the items.
+func getMatchPairsLabel(qn *model.Question, ans *model.Answer) (string, bool) {
+	var labels []string
+	for _, pr := range ans.GetAnsPairs() {
+		l, r := pr.GetLeft(), pr.GetRight()
+		if l < 0 || l >= int64(len(qn.GetChoices())) || r < 0 || r >= int64(len(qn.GetMatchChoices())) {
+			return "", false
+		}
+		labels = append(labels, qn.GetChoices()[l].GetHtmlBody()+" → "+qn.GetMatchChoices()[r].GetHtmlBody())
+	}
+	if len(labels) == 0 {
+		return "No pairs", true
+	}
+	return strings.Join(labels, "; "), true
+}
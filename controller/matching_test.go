@@ -0,0 +1,106 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/url"
+	"quizdrum/model"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func pair(left, right int64) *model.MatchPair {
+	return &model.MatchPair{Left: proto.Int64(left), Right: proto.Int64(right)}
+}
+
+func TestMatchingFromPostBody(t *testing.T) {
+	qn, err := GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Capitals"}, "qn-type": {"match"},
+		"mcq-opt": {"France", "Italy", "Spain"}, "qn-match-right": {"Rome\nMadrid\r\nParis\n"},
+		"qn-key-pairs": {"3-b, 1-C,2 - A"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qn.GetMatchChoices()) != 3 || qn.GetMatchChoices()[2].GetHtmlBody() != "Paris" {
+		t.Errorf("want three items in the second column, got %v", qn.GetMatchChoices())
+	}
+	want := []*model.MatchPair{pair(0, 2), pair(1, 0), pair(2, 1)}
+	got := qn.GetAnswerKey().GetCorrectPairs()
+	if len(got) != len(want) {
+		t.Fatalf("got pairs %v, want %v", got, want)
+	}
+	for i := range want {
+		if !proto.Equal(got[i], want[i]) {
+			t.Errorf("got pairs %v, want %v", got, want)
+		}
+	}
+	for _, v := range []string{"1-D", "4-A", "1-A, 1-B", "1B"} {
+		if _, err := GetQuestionFromPostBody(url.Values{
+			"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Capitals"}, "qn-type": {"match"},
+			"mcq-opt": {"France", "Italy", "Spain"}, "qn-match-right": {"Rome\nMadrid\nParis"},
+			"qn-key-pairs": {v},
+		}); err == nil {
+			t.Errorf("want an error for the pairs %q", v)
+		}
+	}
+
+	ans, err := GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-match": {""},
+		"ans-match-2": {"0"}, "ans-match-0": {"2"}, "ans-match-1": {""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getPrintableStringFromAnswer(ans); got != "1-C, 3-A" {
+		t.Errorf("got %q, want the pairs 1-C, 3-A", got)
+	}
+	if got := getAnswerLabel(qn, ans); got != "France → Paris; Spain → Rome" {
+		t.Errorf("got the label %q", got)
+	}
+	if got := getAnswerDisplay(ans, &model.Quiz{}, qn).AnswerDisplayText; got != "France → Paris; Spain → Rome" {
+		t.Errorf("want the quizmaster to see the pairs spelled out, got %q", got)
+	}
+	if points, ok := gradeAnswer(&model.Quiz{}, qn, ans); points != 3 || !ok {
+		t.Errorf("want 3 of the 10 points for one of three pairs, got %v, %v", points, ok)
+	}
+	rows := getMatchRows(qn, ans)
+	if rows[0].Selected != 2 || rows[1].Selected != -1 || rows[2].Selected != 0 {
+		t.Errorf("want the pairs shown to the participant, got %+v", rows)
+	}
+}
+
+func TestGradeMatchingAnswer(t *testing.T) {
+	qn := &model.Question{
+		Type:      model.AnswerType_MATCHING_ANSWER.Enum(),
+		Points:    proto.Int64(4),
+		AnswerKey: &model.AnswerKey{CorrectPairs: []*model.MatchPair{pair(0, 1), pair(1, 0)}},
+	}
+	tests := []struct {
+		pairs  []*model.MatchPair
+		points int64
+	}{
+		{[]*model.MatchPair{pair(0, 1), pair(1, 0)}, 4},
+		{[]*model.MatchPair{pair(0, 1), pair(1, 1)}, 2},
+		// The same pair sent twice counts once.
+		{[]*model.MatchPair{pair(0, 1), pair(0, 1)}, 2},
+		{nil, 0},
+	}
+	for _, tc := range tests {
+		ans := &model.Answer{Type: qn.GetType().Enum(), AnsPairs: tc.pairs}
+		if points, ok := gradeAnswer(&model.Quiz{}, qn, ans); points != tc.points || !ok {
+			t.Errorf("%v: got %v, %v; want %v", tc.pairs, points, ok, tc.points)
+		}
+	}
+}
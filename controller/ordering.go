@@ -0,0 +1,152 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"quizdrum/model"
+	"strconv"
+	"strings"
+)
+
+// orderItem is one of the items of an ordering question, as shown to the participant.
+type orderItem struct {
+	// Index is the position of the item in the question.
+	Index int64
+	Body  string
+}
+
+// getOrderItems returns the items of an ordering question in the order the participant
+// last put them in, or as the question lists them if there is no usable answer yet.
+func getOrderItems(qn *model.Question, ans *model.Answer) []orderItem {
+	order := ans.GetAnsOrder()
+	if !isPermutation(order, len(qn.GetChoices())) {
+		order = nil
+		for i := range qn.GetChoices() {
+			order = append(order, int64(i))
+		}
+	}
+	items := make([]orderItem, 0, len(order))
+	for _, i := range order {
+		items = append(items, orderItem{Index: i, Body: qn.GetChoices()[i].GetHtmlBody()})
+	}
+	return items
+}
+
+// isPermutation reports whether order holds each of 0 to n-1 exactly once.
+func isPermutation(order []int64, n int) bool {
+	if len(order) != n {
+		return false
+	}
+	seen := make([]bool, n)
+	for _, i := range order {
+		if i < 0 || i >= int64(n) || seen[i] {
+			return false
+		}
+		seen[i] = true
+	}
+	return true
+}
+
+// gradeOrderingAnswer compares the order the participant gave with the correct order, and
+// gives a share of the points for partly right orders if the answer key allows it.
+func gradeOrderingAnswer(qz *model.Quiz, qn *model.Question, ans *model.Answer) (int64, bool) {
+	correct := qn.GetAnswerKey().GetCorrectOrder()
+	if len(correct) == 0 {
+		return 0, false
+	}
+	given := ans.GetAnsOrder()
+	var share float64
+	if isPermutation(given, len(correct)) {
+		switch qn.GetAnswerKey().GetOrderingGrading() {
+		case model.OrderingGrading_POSITIONS_CORRECT:
+			var right int
+			for i := range correct {
+				if given[i] == correct[i] {
+					right++
+				}
+			}
+			share = float64(right) / float64(len(correct))
+		case model.OrderingGrading_KENDALL_TAU:
+			share = 1
+			if pairs := len(correct) * (len(correct) - 1) / 2; pairs > 0 {
+				share -= float64(kendallTauDistance(given, correct)) / float64(pairs)
+			}
+		default:
+			share = 1
+			for i := range correct {
+				if given[i] != correct[i] {
+					share = 0
+					break
+				}
+			}
+		}
+	}
+	points := int64(math.Round(float64(getQuestionPoints(qn)) * share))
+	if points <= 0 {
+		return getPointScale(qz, qn).GetWrongAnswerPoints(), true
+	}
+	return points, true
+}
+
+// kendallTauDistance counts the pairs of items that are in a different order in a and b,
+// which must be permutations of the same items.
+func kendallTauDistance(a, b []int64) int {
+	pos := make(map[int64]int, len(b))
+	for i, v := range b {
+		pos[v] = i
+	}
+	var d int
+	for i := range a {
+		for j := i + 1; j < len(a); j++ {
+			if pos[a[i]] > pos[a[j]] {
+				d++
+			}
+		}
+	}
+	return d
+}
+
+// setOrderingAnswerKeyFromFormValues reads qn-key-order, the comma separated numbers of the
+// items in the correct order counting from 1, and qn-key-order-grading, how partly right
+// orders are graded.
+func setOrderingAnswerKeyFromFormValues(p url.Values, numItems int, key *model.AnswerKey) error {
+	for _, v := range strings.Split(p.Get("qn-key-order"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		key.CorrectOrder = append(key.CorrectOrder, i-1)
+	}
+	if !isPermutation(key.CorrectOrder, numItems) {
+		return fmt.Errorf("the correct order must list each of the items 1 to %v once", numItems)
+	}
+	switch p.Get("qn-key-order-grading") {
+	case "", "exact":
+		key.OrderingGrading = model.OrderingGrading_EXACT_ORDER.Enum()
+	case "positions":
+		key.OrderingGrading = model.OrderingGrading_POSITIONS_CORRECT.Enum()
+	case "kendall":
+		key.OrderingGrading = model.OrderingGrading_KENDALL_TAU.Enum()
+	default:
+		return fmt.Errorf("unexpected grading for the order: %v", p.Get("qn-key-order-grading"))
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGradeOrderingAnswer(t *testing.T) {
+	order := func(g model.OrderingGrading) *model.Question {
+		return &model.Question{
+			Type:   model.AnswerType_ORDERING_ANSWER.Enum(),
+			Points: proto.Int64(12),
+			AnswerKey: &model.AnswerKey{
+				CorrectOrder:    []int64{2, 0, 3, 1},
+				OrderingGrading: g.Enum(),
+			},
+		}
+	}
+	exact := order(model.OrderingGrading_EXACT_ORDER)
+	positions := order(model.OrderingGrading_POSITIONS_CORRECT)
+	kendall := order(model.OrderingGrading_KENDALL_TAU)
+	tests := []struct {
+		qn     *model.Question
+		given  []int64
+		points int64
+	}{
+		{exact, []int64{2, 0, 3, 1}, 12},
+		{exact, []int64{0, 2, 3, 1}, 0},
+		{positions, []int64{0, 2, 3, 1}, 6},
+		{positions, []int64{1, 3, 0, 2}, 0},
+		// One of the six pairs is the wrong way round.
+		{kendall, []int64{0, 2, 3, 1}, 10},
+		{kendall, []int64{1, 3, 0, 2}, 0},
+		// Answers that are not an order of the items get nothing.
+		{kendall, []int64{2, 0, 3}, 0},
+		{positions, []int64{2, 2, 3, 1}, 0},
+	}
+	for _, tc := range tests {
+		ans := &model.Answer{Type: tc.qn.GetType().Enum(), AnsOrder: tc.given}
+		if points, ok := gradeAnswer(&model.Quiz{}, tc.qn, ans); points != tc.points || !ok {
+			t.Errorf("%v with %v: got %v, %v; want %v", tc.qn.GetAnswerKey().GetOrderingGrading(), tc.given,
+				points, ok, tc.points)
+		}
+	}
+}
+
+func TestOrderingFromPostBody(t *testing.T) {
+	qn, err := GetQuestionFromPostBody(url.Values{
+		"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Oldest first"}, "qn-type": {"order"},
+		"mcq-opt": {"Moon landing", "Magna Carta", "Printing press"}, "qn-key-order": {"2, 3, 1"},
+		"qn-key-order-grading": {"kendall"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(qn.GetAnswerKey().GetCorrectOrder(), []int64{1, 2, 0}) ||
+		qn.GetAnswerKey().GetOrderingGrading() != model.OrderingGrading_KENDALL_TAU {
+		t.Errorf("want the order 1, 2, 0 graded by pairs, got %v", qn)
+	}
+	for _, v := range []string{"1, 2", "1, 2, 2", "1, 2, 4"} {
+		if _, err := GetQuestionFromPostBody(url.Values{
+			"quiz-id": {"1"}, "qn-title": {"Q"}, "qn-body": {"Oldest first"}, "qn-type": {"order"},
+			"mcq-opt": {"A", "B", "C"}, "qn-key-order": {v},
+		}); err == nil {
+			t.Errorf("want an error for the correct order %q", v)
+		}
+	}
+
+	ans, err := GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-order": {"2", "0", "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.GetType() != model.AnswerType_ORDERING_ANSWER || !reflect.DeepEqual(ans.GetAnsOrder(), []int64{2, 0, 1}) {
+		t.Errorf("want the order 2, 0, 1, got %v", ans)
+	}
+	if _, err := GetAnswerFromPostBody(url.Values{"qn-id": {"1"}, "ans-order": {"2", "0", "0"}}); err == nil {
+		t.Errorf("want an error for an item given twice")
+	}
+	if got := getAnswerLabel(qn, ans); got != "Printing press, then Moon landing, then Magna Carta" {
+		t.Errorf("got the label %q", got)
+	}
+}
+
+func TestGetOrderItems(t *testing.T) {
+	qn := &model.Question{Choices: []*model.AnswerChoice{
+		{HtmlBody: proto.String("A")}, {HtmlBody: proto.String("B")}, {HtmlBody: proto.String("C")}}}
+	want := []orderItem{{0, "A"}, {1, "B"}, {2, "C"}}
+	if got := getOrderItems(qn, nil); !reflect.DeepEqual(got, want) {
+		t.Errorf("with no answer got %v, want %v", got, want)
+	}
+	want = []orderItem{{2, "C"}, {0, "A"}, {1, "B"}}
+	if got := getOrderItems(qn, &model.Answer{AnsOrder: []int64{2, 0, 1}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("with an answer got %v, want %v", got, want)
+	}
+}
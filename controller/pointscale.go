@@ -0,0 +1,126 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// defaultPresets are the score buttons for quizzes that do not set their own.
+	defaultPresets = []int64{0, 5, 10}
+)
+
+// getPointScale returns the point scale for answers to the question: its own, or else that of
+// its round, or else that of the quiz. qn may be nil if the question is not known, in which case
+// the scale of the quiz is used.
+func getPointScale(qz *model.Quiz, qn *model.Question) *model.PointScale {
+	if qn.GetPointScale() != nil {
+		return qn.GetPointScale()
+	}
+	if rd := getRound(qz, qn.GetRoundId()); rd.GetPointScale() != nil {
+		return rd.GetPointScale()
+	}
+	if qz.GetPointScale() != nil {
+		return qz.GetPointScale()
+	}
+	return &model.PointScale{}
+}
+
+// getPresets returns the score buttons of the point scale.
+func getPresets(scale *model.PointScale) []int64 {
+	if len(scale.GetPresets()) == 0 {
+		return defaultPresets
+	}
+	return scale.GetPresets()
+}
+
+// checkScore returns an error if the points are outside the bounds of the point scale.
+func checkScore(scale *model.PointScale, points int64) error {
+	if scale.MinPoints != nil && points < scale.GetMinPoints() {
+		return fmt.Errorf("%v points is below the lowest score of %v", points, scale.GetMinPoints())
+	}
+	if scale.MaxPoints != nil && points > scale.GetMaxPoints() {
+		return fmt.Errorf("%v points is above the highest score of %v", points, scale.GetMaxPoints())
+	}
+	return nil
+}
+
+// clampScore returns the points, moved into the bounds of the point scale if they are outside.
+func clampScore(scale *model.PointScale, points int64) int64 {
+	if scale.MinPoints != nil {
+		points = max(points, scale.GetMinPoints())
+	}
+	if scale.MaxPoints != nil {
+		points = min(points, scale.GetMaxPoints())
+	}
+	return points
+}
+
+// getPointScaleFromFormValues reads a point scale from the fields that start with prefix:
+// -presets is a comma separated list, -wrong the points for a wrong answer, and -min and -max
+// the bounds. It returns nil if none of them are set.
+func getPointScaleFromFormValues(p url.Values, prefix string) (*model.PointScale, error) {
+	var scale model.PointScale
+	set := false
+	for _, v := range strings.Split(p.Get(prefix+"-presets"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		pts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		scale.Presets = append(scale.Presets, pts)
+		set = true
+	}
+	for _, f := range []struct {
+		suffix string
+		field  **int64
+	}{
+		{"-wrong", &scale.WrongAnswerPoints},
+		{"-min", &scale.MinPoints},
+		{"-max", &scale.MaxPoints},
+	} {
+		v := p.Get(prefix + f.suffix)
+		if v == "" {
+			continue
+		}
+		pts, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		*f.field = proto.Int64(pts)
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	if scale.MinPoints != nil && scale.MaxPoints != nil && scale.GetMinPoints() > scale.GetMaxPoints() {
+		return nil, fmt.Errorf("the lowest score %v is above the highest score %v", scale.GetMinPoints(), scale.GetMaxPoints())
+	}
+	for _, pts := range append([]int64{scale.GetWrongAnswerPoints()}, getPresets(&scale)...) {
+		if err := checkScore(&scale, pts); err != nil {
+			return nil, err
+		}
+	}
+	return &scale, nil
+}
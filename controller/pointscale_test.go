@@ -0,0 +1,135 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetPointScaleFromFormValues(t *testing.T) {
+	scale, err := getPointScaleFromFormValues(url.Values{
+		"qz-scale-presets": {"1, 2,3"}, "qz-scale-wrong": {"-1"}, "qz-scale-min": {"-1"}, "qz-scale-max": {"3"},
+	}, "qz-scale")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(scale.GetPresets(), []int64{1, 2, 3}) || scale.GetWrongAnswerPoints() != -1 ||
+		scale.GetMinPoints() != -1 || scale.GetMaxPoints() != 3 {
+		t.Errorf("want presets 1, 2, 3 from -1 to 3, got %v", scale)
+	}
+	if scale, err := getPointScaleFromFormValues(url.Values{"qz-scale-presets": {""}}, "qz-scale"); scale != nil || err != nil {
+		t.Errorf("want no point scale from an empty form, got %v, %v", scale, err)
+	}
+	for _, v := range []url.Values{
+		{"qz-scale-min": {"3"}, "qz-scale-max": {"1"}},
+		{"qz-scale-presets": {"1, two"}},
+		// The default buttons must fit the bounds too.
+		{"qz-scale-max": {"3"}},
+		{"qz-scale-presets": {"1, 2, 3"}, "qz-scale-wrong": {"-1"}, "qz-scale-min": {"0"}},
+	} {
+		if _, err := getPointScaleFromFormValues(v, "qz-scale"); err == nil {
+			t.Errorf("want an error for %v", v)
+		}
+	}
+}
+
+func TestAnswerKeyKeepsToPointScale(t *testing.T) {
+	qz := &model.Quiz{PointScale: &model.PointScale{MinPoints: proto.Int64(0), MaxPoints: proto.Int64(10)}}
+	qn := &model.Question{
+		Type:      model.AnswerType_TEXT_ANSWER.Enum(),
+		Points:    proto.Int64(20),
+		AnswerKey: &model.AnswerKey{AcceptedTexts: []string{"Paris"}},
+	}
+	ans := &model.Answer{Type: model.AnswerType_TEXT_ANSWER.Enum(), AnsText: proto.String("Paris")}
+	if applyAnswerKey(qz, qn, ans); ans.GetPointsAwarded() != 10 {
+		t.Errorf("want the 20 points of the question cut to the highest score of 10, got %v", ans.GetPointsAwarded())
+	}
+	qn.PointScale = &model.PointScale{WrongAnswerPoints: proto.Int64(-5)}
+	ans.AnsText = proto.String("Rome")
+	if applyAnswerKey(qz, qn, ans); ans.GetPointsAwarded() != -5 {
+		t.Errorf("want the -5 points of the scale of the question, got %v", ans.GetPointsAwarded())
+	}
+}
+
+func TestGetIDToScoreMapFromPostForm(t *testing.T) {
+	scale := &model.PointScale{Presets: []int64{1, 2, 3}, MinPoints: proto.Int64(-1), MaxPoints: proto.Int64(3)}
+	got, err := getIDToScoreMapFromPostForm(url.Values{
+		"ans-4-score": {"2"}, "ans-5-score": {"custom"}, "ans-5-custom-score": {"-1"},
+	}, scale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[int64]int64{4: 2, 5: -1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	for _, v := range []url.Values{
+		{"ans-4-score": {"5"}},
+		{"ans-4-score": {"custom"}, "ans-4-custom-score": {"-2"}},
+	} {
+		if _, err := getIDToScoreMapFromPostForm(v, scale); err == nil {
+			t.Errorf("want an error for the out of bounds score in %v", v)
+		}
+	}
+}
+
+func TestPointScaleOfQuestion(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	r := callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/updateproperties", tq.qzid),
+		"qz-title=League&qz-descr=League&qz-scale-presets=1,2,3&qz-scale-min=0&qz-scale-max=3",
+		tq.qmCookie, map[string]string{"quizid": tq.qzid}, c.UpdateQuizProperties)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to set the point scale. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	// This question uses negative marking instead of the scale of the quiz.
+	qnid := tq.addQuestion(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-key-text=Paris&qn-points=2"+
+		"&qn-scale-presets=-1,0,2&qn-scale-wrong=-1&qn-scale-min=-1&qn-scale-max=2")
+	tq.setActive(t, c, qnid)
+	r = callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Rome", tq.qzid, qnid), tq.ppCookie, nil, c.SubmitAnswer)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	ansid := r.resptext
+
+	qn, _ := strconv.ParseInt(qnid, 10, 64)
+	answers, err := p.GetAllAnswersToQuestionID(uint(qn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 1 || answers[0].GetPointsAwarded() != -1 {
+		t.Errorf("want the wrong answer marked down to -1, got %v", answers)
+	}
+
+	vars := map[string]string{"questionid": qnid}
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", qnid),
+		fmt.Sprintf("ans-%v-score=3", ansid), tq.qmCookie, vars, c.SaveScores)
+	if r.statuscode != 400 {
+		t.Errorf("want: HTTP 400 for a score above the maximum of the question. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	m := c.handleAnswerFeedMessage(id, nil, &answerFeedMessage{Type: "scores", QuestionID: qn, Scores: map[int64]int64{1: -2}})
+	if m.Type != "error" {
+		t.Errorf("want an error for a score below the minimum from the answer feed, got %v", m)
+	}
+}
@@ -16,11 +16,13 @@ package controller
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"quizdrum/model"
 	"quizdrum/view"
+	"slices"
 	"strconv"
 	"time"
 
@@ -28,6 +30,12 @@ import (
 	"google.golang.org/protobuf/proto"
 )
 
+var (
+	statusKeepAliveInterval = 25 * time.Second
+
+	errQuestionNotLive = errors.New("the question is not the live question of the quiz")
+)
+
 // SetProfile is the API handler that persists the profile name for this participant
 func (c *Controller) SetProfile(w http.ResponseWriter, r *http.Request) {
 	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
@@ -56,7 +64,9 @@ func (c *Controller) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
 		return
 	}
-	r.ParseForm()
+	if !parseAnswerForm(w, r) {
+		return
+	}
 
 	qzid, err := strconv.Atoi(r.FormValue("qz-id"))
 	if view.Should500(err, w, "could not parse quiz id") {
@@ -66,34 +76,109 @@ func (c *Controller) SubmitAnswer(w http.ResponseWriter, r *http.Request) {
 	if view.Should500(err, w, "could not fetch quiz") {
 		return
 	}
-	if !qz.GetAcceptingResponses() {
+	// Answers sent just as the countdown ran out are still accepted.
+	if !isAcceptingResponses(qz, time.Now().Add(-deadlineGracePeriod)) {
 		w.WriteHeader(http.StatusConflict)
 		fmt.Fprintf(w, "the quiz is not accepting responses right now")
 		return
 	}
+	qnid, err := strconv.Atoi(r.FormValue("qn-id"))
+	if view.Should500(err, w, "could not parse the question id") {
+		return
+	}
+	qn, err := c.P.GetQuestionByID(uint(qnid))
+	if view.Should500(err, w, "could not find the question") {
+		return
+	}
+	if err := validateLiveQuestion(qz, qn); errors.Is(err, errQuestionNotLive) {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	}
+	storedUpload, replacedUpload, err := c.storeUploadFromForm(r, qz, qn, u)
+	if errors.Is(err, errUploadTooLarge) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if errors.Is(err, errUploadType) {
+		w.WriteHeader(http.StatusUnsupportedMediaType)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if errors.Is(err, errUploadMissing) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not store the file") {
+		return
+	}
+	// The new file is only kept if the answer that points at it is saved.
+	saved := false
+	defer func() {
+		if storedUpload != 0 && !saved {
+			c.deleteUpload(storedUpload)
+		}
+	}()
 
 	ans, err := GetAnswerFromPostBody(r.PostForm)
 	if view.Should500(err, w, "could not construct the answer from the post body") {
 		return
 	}
+	if ans.GetType() != qn.GetType() {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "the question needs a %v, not a %v", qn.GetType(), ans.GetType())
+		return
+	}
 
-	ans.ResponseTimeS = proto.Int64(time.Now().Unix())
+	now := time.Now()
+	ans.ResponseTimeS = proto.Int64(now.Unix())
 	ans.SolverId = proto.Int64(u.GetId())
-	// TODO: validate that the answer type matches the question type
-	if ans.GetId() != 0 {
+	setElapsedTime(qz, ans, now)
+	if qn.GetWager() {
+		most, err := c.getMaxWager(qz.GetId(), qn.GetId(), u.GetId())
+		if view.Should500(err, w, "could not work out the largest wager") {
+			return
+		}
+		if err := setWagerFromPostForm(r.PostForm, ans, most); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "%v", err)
+			return
+		}
+	}
+	applyAnswerKey(qz, qn, ans)
+	aid := uint(ans.GetId())
+	if aid != 0 {
 		// Update
 		if view.Should500(c.P.UpdateAnswer(ans), w, "could not update the answer") {
 			return
 		}
-		view.WriteJSONString(w, fmt.Sprint(ans.GetId()))
 	} else {
 		// Create
-		aid, err := c.P.CreateAnswer(ans)
+		aid, err = c.P.CreateAnswer(ans)
 		if view.Should500(err, w, "could not store the answer") {
 			return
 		}
-		view.WriteJSONString(w, fmt.Sprint(aid))
 	}
+	saved = true
+	// The answer no longer points at the file it replaced.
+	if replacedUpload != 0 {
+		c.deleteUpload(replacedUpload)
+	}
+	view.WriteJSONString(w, fmt.Sprint(aid))
+}
+
+// validateLiveQuestion returns an error unless the question is the live question of the quiz.
+func validateLiveQuestion(qz *model.Quiz, qn *model.Question) error {
+	if qn.GetQuizId() != qz.GetId() {
+		return fmt.Errorf("question %v is not in quiz %v", qn.GetId(), qz.GetId())
+	}
+	if qn.GetId() != qz.GetLiveQuestionId() {
+		return errQuestionNotLive
+	}
+	return nil
 }
 
 // GetQuizStatus returns the current question ID and whether answers are being accepted.
@@ -107,20 +192,88 @@ func (c *Controller) GetQuizStatus(w http.ResponseWriter, r *http.Request) {
 	if view.Should500(err, w, "could not fetch quiz") {
 		return
 	}
-	resp := struct {
-		QuestionID         int64
-		AcceptingResponses bool
-	}{
-		QuestionID:         qz.GetLiveQuestionId(),
-		AcceptingResponses: qz.GetAcceptingResponses(),
-	}
-	b, err := json.Marshal(resp)
+	b, err := json.Marshal(getQuizStatus(qz, time.Now()))
 	if view.Should500(err, w, "could not build a json response") {
 		return
 	}
 	view.WriteJSONBytes(w, b)
 }
 
+// StreamQuizStatus pushes the quiz status to the participant as server-sent events
+// whenever the quizmaster changes it. Clients that cannot use this should poll GetQuizStatus.
+func (c *Controller) StreamQuizStatus(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	fl, ok := w.(http.Flusher)
+	if !ok {
+		view.Should500(fmt.Errorf("response writer does not support flushing"), w, "streaming is not supported")
+		return
+	}
+
+	// Subscribe before reading the current status so that no change is missed in between.
+	updates, unsubscribe := c.status.subscribe(int64(qzid))
+	defer unsubscribe()
+	qz, err := c.P.GetQuizWithoutQuestions(int64(qzid))
+	if view.Should500(err, w, "could not fetch quiz") {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	if err := writeStatusEvent(w, getQuizStatus(qz, time.Now())); err != nil {
+		return
+	}
+	fl.Flush()
+
+	keepAlive := time.NewTicker(statusKeepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case st := <-updates:
+			if err := writeStatusEvent(w, st); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			// A comment line keeps proxies from closing an idle connection.
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		fl.Flush()
+	}
+}
+
+func writeStatusEvent(w http.ResponseWriter, st quizStatus) error {
+	b, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "data: %s\n\n", b)
+	return err
+}
+
+func getQuizStatus(qz *model.Quiz, now time.Time) quizStatus {
+	st := quizStatus{
+		QuestionID:         qz.GetLiveQuestionId(),
+		AcceptingResponses: isAcceptingResponses(qz, now),
+		Revealed:           qz.GetAnswerRevealed(),
+		RoundID:            qz.GetLiveRoundId(),
+	}
+	if deadline, ok := getResponseDeadline(qz); ok && st.AcceptingResponses {
+		st.RemainingMs = deadline.Sub(now).Milliseconds()
+	}
+	if inLobby(qz) {
+		st.Lobby = getLobbyStatus(qz, now)
+	}
+	return st
+}
+
 // GetAnswerFromPostBody builds a Answer proto from the submitted form
 func GetAnswerFromPostBody(p url.Values) (*model.Answer, error) {
 	var ans model.Answer
@@ -176,6 +329,52 @@ func GetAnswerFromPostBody(p url.Values) (*model.Answer, error) {
 		}
 		ans.AnsChoiceIndex = proto.Int64(ansind)
 		ans.Type = model.AnswerType_MULTIPLE_CHOICE_ANSWER.Enum()
+	} else if val, ok := p["ans-msq"]; ok {
+		// The form always sends an empty ans-msq, so that picking none of the options
+		// is still an answer.
+		for _, v := range val {
+			if v == "" {
+				continue
+			}
+			ansind, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			if !slices.Contains(ans.AnsChoiceIndices, ansind) {
+				ans.AnsChoiceIndices = append(ans.AnsChoiceIndices, ansind)
+			}
+		}
+		slices.Sort(ans.AnsChoiceIndices)
+		ans.Type = model.AnswerType_MULTI_SELECT_ANSWER.Enum()
+	} else if val, ok := p["ans-order"]; ok {
+		for _, v := range val {
+			ansind, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, err
+			}
+			ans.AnsOrder = append(ans.AnsOrder, ansind)
+		}
+		if !isPermutation(ans.AnsOrder, len(ans.AnsOrder)) {
+			return nil, fmt.Errorf("the order must list each item once: %v", ans.AnsOrder)
+		}
+		ans.Type = model.AnswerType_ORDERING_ANSWER.Enum()
+	} else if _, ok := p["ans-match"]; ok {
+		// The form always sends an empty ans-match, since leaving every item unmatched
+		// sends none of the ans-match-<item> fields.
+		if ans.AnsPairs, err = getMatchPairsFromPostForm(p); err != nil {
+			return nil, err
+		}
+		ans.Type = model.AnswerType_MATCHING_ANSWER.Enum()
+	} else if val, ok := p["ans-blank"]; ok {
+		ans.AnsBlanks = val
+		ans.Type = model.AnswerType_CLOZE_ANSWER.Enum()
+	} else if val, ok := p["ans-upload"]; ok {
+		upid, err := strconv.ParseInt(val[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		ans.UploadId = proto.Int64(upid)
+		ans.Type = model.AnswerType_UPLOAD_ANSWER.Enum()
 	} else {
 		return nil, fmt.Errorf("Did not get any supported answer type")
 	}
@@ -0,0 +1,155 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSubmitAnswerToLiveQuestion(t *testing.T) {
+	c, p := newTestController(t)
+	tq := newTestQuiz(t, c, "qn-title=Q1&qn-body=Capital&qn-type=text&qn-key-text=Paris")
+	first := tq.qnid
+	second := tq.addQuestion(t, c, "qn-title=Q2&qn-body=River&qn-type=text&qn-key-text=Seine")
+	tq.setActive(t, c, second)
+	other := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-key-text=Rome")
+
+	submit := func(qnid, form string) savedHTTPResponse {
+		return callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&%v", tq.qzid, qnid, form), tq.ppCookie, nil, c.SubmitAnswer)
+	}
+	if r := submit(first, "ans-text=Paris"); r.statuscode != http.StatusConflict {
+		t.Errorf("want: HTTP 409 for a question that is no longer live. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submit(other.qnid, "ans-text=Rome"); r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a question of another quiz. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submit(second, "ans-int64=7"); r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for the wrong type of answer. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submit(second, "ans-text=Seine"); r.statuscode != http.StatusOK {
+		t.Errorf("could not answer the live question: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	// The answer to an earlier question cannot be moved to the live one.
+	tq.setActive(t, c, first)
+	r := submit(first, "ans-text=Paris")
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not answer the live question: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	ansid := strings.Trim(r.resptext, "\"\n")
+	tq.setActive(t, c, second)
+	if r := submit(second, "ans-id="+ansid+"&ans-text=wrong"); r.statuscode == http.StatusOK {
+		t.Errorf("want an error for the answer to another question. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	id, _ := strconv.Atoi(ansid)
+	ans, err := p.GetAnswerByID(uint(id))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qnid := strconv.FormatInt(ans.GetQuestionId(), 10); qnid != first || ans.GetAnsText() != "Paris" {
+		t.Errorf("want the answer to stay with question %v, got %v", first, ans)
+	}
+}
+
+func TestResubmitKeepsScoreSetByHand(t *testing.T) {
+	c, p := newTestController(t)
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris")
+	submit := func(form string) string {
+		t.Helper()
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&%v", tq.qzid, tq.qnid, form), tq.ppCookie, nil, c.SubmitAnswer)
+		if r.statuscode != http.StatusOK {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		return strings.Trim(r.resptext, "\"\n")
+	}
+	ansid := submit("ans-text=Paris+France")
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=2", ansid), tq.qmCookie, map[string]string{"questionid": tq.qnid}, c.SaveScores)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to save scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	// Both ways of resubmitting keep the points the quizmaster gave, even for an answer the key grades.
+	for _, form := range []string{"ans-text=Paris", "ans-id=" + ansid + "&ans-text=Paris"} {
+		submit(form)
+		id, _ := strconv.Atoi(ansid)
+		ans, err := p.GetAnswerByID(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ans.GetPointsAwarded() != 2 || !ans.GetPointsOverridden() || ans.GetAnsText() != "Paris" {
+			t.Errorf("after resubmitting with %v, want the new answer with the 2 points set by hand, got %v", form, ans)
+		}
+	}
+}
+
+func TestZeroSetByHandSurvivesGrading(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+	// The question has no answer key yet, so the answer is not graded when it is submitted.
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4")
+	submit := func() {
+		t.Helper()
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Paris", tq.qzid, tq.qnid), tq.ppCookie, nil, c.SubmitAnswer)
+		if r.statuscode != http.StatusOK {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+	}
+	check := func(when string) {
+		t.Helper()
+		id, _ := strconv.Atoi(tq.qnid)
+		answers, err := p.GetAllAnswersToQuestionID(uint(id))
+		if err != nil || len(answers) != 1 {
+			t.Fatalf("want one answer, got %v. %v", answers, err)
+		}
+		if ans := answers[0]; ans.PointsAwarded == nil || ans.GetPointsAwarded() != 0 || !ans.GetPointsOverridden() {
+			t.Errorf("%v, want the 0 points set by hand, got %v", when, ans)
+		}
+	}
+	submit()
+	id, _ := strconv.Atoi(tq.qnid)
+	answers, err := p.GetAllAnswersToQuestionID(uint(id))
+	if err != nil || len(answers) != 1 {
+		t.Fatalf("want one answer, got %v. %v", answers, err)
+	}
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=0", answers[0].GetId()), tq.qmCookie, map[string]string{"questionid": tq.qnid}, c.SaveScores)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to save scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	check("after the quizmaster scored it")
+
+	r = callController("POST", "/api/quizmaster/question/update",
+		fmt.Sprintf("quiz-id=%v&qn-id=%v&qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris",
+			tq.qzid, tq.qnid), tq.qmCookie, nil, c.UpdateQuestion)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to update the question. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/regrade", tq.qzid), "dry-run=false", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.RegradeAnswers)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("Failed to regrade. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	check("after a regrade with the new key")
+	submit()
+	check("after resubmitting")
+}
@@ -20,6 +20,7 @@ import (
 	"quizdrum/model"
 	"quizdrum/view"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"google.golang.org/protobuf/proto"
@@ -87,6 +88,26 @@ func (c *Controller) RenderLiveQuiz(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if inLobby(q) {
+		s := struct {
+			U           *model.User
+			Q           *model.Quiz
+			ProfileName string
+			StartTime   int64
+			Lobby       *lobbyStatus
+			Joker       *jokerStatus
+		}{
+			U:           u,
+			Q:           q,
+			ProfileName: profileName,
+			StartTime:   q.GetExpectedStartTime(),
+			Lobby:       getLobbyStatus(q, time.Now()),
+			Joker:       getJokerStatus(q, u.GetId()),
+		}
+		c.V.RenderTemplate(w, "pp_lobby.html", s)
+		return
+	}
+
 	var qn *model.Question
 	for _, qni := range q.GetQuestions() {
 		if qni.GetId() == q.GetLiveQuestionId() {
@@ -103,11 +124,31 @@ func (c *Controller) RenderLiveQuiz(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var ans *model.Answer
+	var ansText string
 	if qn != nil {
 		a, err := c.P.GetAnswerByUserAndQuestion(u, qn)
 		// Ignoring errors here, since it could just be the case that the answer does not exist
 		if err == nil {
 			ans = a
+			ansText = getAnswerLabel(qn, a)
+		}
+	}
+
+	// Once the answer is revealed, show everyone how the others answered.
+	var tally []answerTally
+	if q.GetAnswerRevealed() && qn.GetId() != 0 {
+		answers, err := c.P.GetAllAnswersToQuestionID(uint(qn.GetId()))
+		if view.Should500(err, w, "could not fetch answers") {
+			return
+		}
+		tally = getAnswerTally(qn, answers, u.GetId())
+	}
+
+	var maxWager int64
+	if qn.GetWager() {
+		maxWager, err = c.getMaxWager(q.GetId(), qn.GetId(), u.GetId())
+		if view.Should500(err, w, "could not work out the largest wager") {
+			return
 		}
 	}
 
@@ -117,12 +158,30 @@ func (c *Controller) RenderLiveQuiz(w http.ResponseWriter, r *http.Request) {
 		Qn          *model.Question
 		ProfileName string
 		Ans         *model.Answer
+		AnsText     string
+		SpeedBonus  int64
+		Tally       []answerTally
+		OrderItems  []orderItem
+		MatchRows   []matchRow
+		ClozeParts  []clozePart
+		MaxWager    int64
+		Score       int64
+		Joker       *jokerStatus
 	}{
 		U:           u,
 		Q:           q,
 		Qn:          qn,
 		ProfileName: profileName,
 		Ans:         ans,
+		AnsText:     ansText,
+		SpeedBonus:  getSpeedBonus(q, qn, ans),
+		Tally:       tally,
+		OrderItems:  getOrderItems(qn, ans),
+		MatchRows:   getMatchRows(qn, ans),
+		ClozeParts:  getClozeParts(qn, ans),
+		MaxWager:    maxWager,
+		Score:       getAnswerScore(q, qn, ans),
+		Joker:       getJokerStatus(q, u.GetId()),
 	}
 
 	c.V.RenderTemplate(w, "pp_live.html", s)
@@ -148,57 +207,20 @@ func (c *Controller) RenderScoreboard(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	type participantAndScores struct {
-		ParticipantName string
-		Total           int64
-		Score           []int64
-	}
 	type scbd struct {
-		QuizName      string
-		QuestionTitle []string
-		PAndScore     []participantAndScores
-		U             *model.User
-		ProfileName   string
+		scoreboard
+		QuizName    string
+		U           *model.User
+		ProfileName string
 	}
 
 	var board scbd
+	board.scoreboard = getScoreboard(qz, ansmap)
+	board.sortByTotal()
 	board.QuizName = qz.GetTitle()
 	board.U = u
 	board.ProfileName = findProfileNameFromQuizAndUser(qz, u)
 
-	// First, we arrange the participants in some order
-	ppToIndex := make(map[int64]int)
-	for i, pp := range qz.GetParticipants() {
-		ppToIndex[pp.GetUserId()] = i
-		//board.ParticipantName = append(board.ParticipantName, pp.GetProfileName())
-	}
-
-	// Next, we arrange the questions in some order
-	// TODO: change this to depend on the question_sequence variable
-	qnToIndex := make(map[int64]int)
-	for i, qn := range qz.GetQuestions() {
-		qnToIndex[qn.GetId()] = i
-		board.QuestionTitle = append(board.QuestionTitle, qn.GetTitle())
-	}
-
-	// Now, we run through the participants and write down their names
-	board.PAndScore = make([]participantAndScores, len(ppToIndex))
-	for _, pp := range qz.GetParticipants() {
-		y := ppToIndex[pp.GetUserId()]
-		board.PAndScore[y].ParticipantName = pp.GetProfileName()
-		board.PAndScore[y].Score = make([]int64, len(qnToIndex))
-	}
-
-	// Now we fill out the score tables
-	for _, qn := range qz.GetQuestions() {
-		x := qnToIndex[qn.GetId()]
-		for _, ans := range ansmap[qn] {
-			y := ppToIndex[ans.GetSolverId()]
-			board.PAndScore[y].Score[x] = ans.GetPointsAwarded()
-			board.PAndScore[y].Total += ans.GetPointsAwarded()
-		}
-	}
-
 	c.V.RenderTemplate(w, "pp_scoreboard.html", board)
 }
 
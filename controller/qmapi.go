@@ -15,15 +15,21 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"net/url"
 	"quizdrum/model"
 	"quizdrum/view"
 	"regexp"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 )
@@ -31,6 +37,10 @@ import (
 var (
 	scoreFormName       = regexp.MustCompile(`ans-([0-9]+)-score`)
 	scoreFormNameCustom = regexp.MustCompile(`ans-([0-9]+)-custom-score`)
+	groupScoreFormName  = regexp.MustCompile(`^grp-([0-9]+)-score$`)
+
+	answerFeedUpgrader     = websocket.Upgrader{}
+	answerFeedPingInterval = 30 * time.Second
 )
 
 // NewQuiz is the API handler that creates a new Quiz
@@ -65,6 +75,13 @@ func (c *Controller) NewQuestion(w http.ResponseWriter, r *http.Request) {
 	if view.UnauthIfError(c.P.ValidateWritePrivileges(qn.GetQuizId(), u), w, "no write privileges") {
 		return
 	}
+	if err := c.validateRound(qn); errors.Is(err, model.ErrRoundNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not load the quiz") {
+		return
+	}
 
 	qnid, err := c.P.CreateQuestion(qn)
 	if view.Should500(err, w, "could not save the question") {
@@ -121,12 +138,47 @@ func (c *Controller) UpdateQuestion(w http.ResponseWriter, r *http.Request) {
 	if view.UnauthIfError(c.P.ValidateWritePrivileges(qn.GetQuizId(), u), w, "no write privileges") {
 		return
 	}
-	if view.Should500(c.P.SaveQuestion(qn), w, "could not save the question") {
+	if err := c.validateRound(qn); errors.Is(err, model.ErrRoundNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not load the quiz") {
+		return
+	}
+	// The question bank fields are not in the form. qn-unlink turns a linked question into a copy.
+	qn.BankQuestionId = qnFromDb.BankQuestionId
+	qn.LinkedToBank = qnFromDb.LinkedToBank
+	if r.PostForm.Get("qn-unlink") == "true" {
+		qn.LinkedToBank = nil
+	}
+	if view.Should500(c.saveQuestion(qn, u), w, "could not save the question") {
 		return
 	}
 	fmt.Fprintln(w, "written")
 }
 
+// saveQuestion saves the question, along with the bank question it is linked to and every
+// other question linked to that. A user who neither owns the bank question nor can write to
+// every quiz that uses it cannot change them, so their question is saved as an unlinked copy.
+func (c *Controller) saveQuestion(qn *model.Question, u *model.User) error {
+	if !qn.GetLinkedToBank() {
+		return c.P.SaveQuestion(qn)
+	}
+	bq, err := c.P.GetBankQuestion(uint(qn.GetBankQuestionId()))
+	if err != nil {
+		return err
+	}
+	if bq.GetOwnerId() != u.GetId() {
+		for _, qzid := range bq.GetUsedInQuizIds() {
+			if c.P.ValidateWritePrivileges(qzid, u) != nil {
+				qn.LinkedToBank = nil
+				return c.P.SaveQuestion(qn)
+			}
+		}
+	}
+	return c.P.SaveLinkedQuestion(qn)
+}
+
 // DeleteQuestion is the API handler that soft-deletes a question
 func (c *Controller) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
 	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
@@ -151,6 +203,45 @@ func (c *Controller) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintln(w, "deleted")
 }
 
+// ReorderQuestions is the API handler that saves a new order for the questions of the quiz.
+// The form value qn-ids lists every question ID of the quiz, comma-separated, in the new order.
+func (c *Controller) ReorderQuestions(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	r.ParseForm()
+	qnids := make([]int64, 0)
+	for _, s := range strings.Split(r.PostForm.Get("qn-ids"), ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+		qnid, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "could not parse the question id %q", s)
+			return
+		}
+		qnids = append(qnids, qnid)
+	}
+	if err := c.P.ReorderQuestions(int64(qzid), qnids); errors.Is(err, model.ErrWrongQuestionSequence) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not reorder the questions") {
+		return
+	}
+	fmt.Fprintln(w, "reordered")
+}
+
 // SetActiveQuestionID sets the active question during a live quiz session.
 func (c *Controller) SetActiveQuestionID(w http.ResponseWriter, r *http.Request) {
 	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
@@ -177,8 +268,7 @@ func (c *Controller) SetActiveQuestionID(w http.ResponseWriter, r *http.Request)
 	}
 	for _, qn := range qz.GetQuestions() {
 		if qn.GetId() == int64(qnid) {
-			qz.LiveQuestionId = proto.Int64(int64(qnid))
-			qz.AcceptingResponses = proto.Bool(true)
+			activateQuestion(qz, qn, time.Now())
 			if view.Should500(c.P.SaveQuiz(qz), w, "could not save quiz") {
 				return
 			}
@@ -216,13 +306,57 @@ func (c *Controller) SetAcceptingResponses(w http.ResponseWriter, r *http.Reques
 		return
 	}
 	qz.AcceptingResponses = proto.Bool(ac)
+	// The quizmaster has taken over, so any countdown no longer applies.
+	qz.ResponseDeadlineMs = nil
+	if ac {
+		// Answers cannot change while everyone can see the solution.
+		qz.AnswerRevealed = proto.Bool(false)
+	}
 	if view.Should500(c.P.SaveQuiz(qz), w, "could not save quiz") {
 		return
 	}
 	fmt.Fprintf(w, "set accepting responses to %v", r.FormValue("ar"))
 }
 
-// GetAllAnswersForQuestion finds all the answers for this question
+// SetAnswerRevealed shows or hides the solution to the live question, along with how everyone
+// answered. Revealing the answer stops the quiz from accepting responses.
+func (c *Controller) SetAnswerRevealed(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	r.ParseForm()
+	rv := r.FormValue("rv") == "true"
+	qz, err := c.P.GetQuizWithoutQuestions(int64(qzid))
+	if view.Should500(err, w, "could not get quiz") {
+		return
+	}
+	if rv && qz.GetLiveQuestionId() == 0 {
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, "there is no live question to reveal")
+		return
+	}
+	qz.AnswerRevealed = proto.Bool(rv)
+	if rv {
+		qz.AcceptingResponses = proto.Bool(false)
+		qz.ResponseDeadlineMs = nil
+	}
+	if view.Should500(c.P.SaveQuiz(qz), w, "could not save quiz") {
+		return
+	}
+	fmt.Fprintf(w, "set answer revealed to %v", rv)
+}
+
+// GetAllAnswersForQuestion finds all the answers for this question. With grouped=1, answers
+// that are the same once normalized are shown together.
 func (c *Controller) GetAllAnswersForQuestion(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	qnid, err := strconv.Atoi(vars["questionid"])
@@ -243,34 +377,20 @@ func (c *Controller) GetAllAnswersForQuestion(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	type ansDisplay struct {
-		AnswerID            int64
-		SolverID            int64
-		SolverProfileName   string
-		AnswerDisplayText   string
-		ResponseTimeS       int64
-		PointsAwarded       int64
-		CustomPointsAwarded bool
+	qn, err := c.P.GetQuestionByID(uint(qnid))
+	if view.Should500(err, w, "could not find the question") {
+		return
+	}
+
+	// With many participants, the quizmaster can grade identical answers together.
+	if r.FormValue("grouped") == "1" {
+		c.V.RenderTemplate(w, "qm_answer_groups.html", getAnswerGroups(qz, qn, sansa))
+		return
 	}
 
-	dasp := make([]ansDisplay, 0)
+	dasp := make([]*answerDisplay, 0)
 	for _, ans := range sansa {
-		var ad ansDisplay
-		ad.AnswerID = ans.GetId()
-		ad.SolverID = ans.GetSolverId()
-		for _, prf := range qz.GetParticipants() {
-			if ad.SolverID == int64(prf.GetUserId()) {
-				ad.SolverProfileName = prf.GetProfileName()
-				break
-			}
-		}
-		ad.AnswerDisplayText = getPrintableStringFromAnswer(ans)
-		ad.ResponseTimeS = ans.GetResponseTimeS()
-		ad.PointsAwarded = ans.GetPointsAwarded()
-		ad.CustomPointsAwarded = !(ans.GetPointsAwarded() == 0 ||
-			ans.GetPointsAwarded() == 5 ||
-			ans.GetPointsAwarded() == 10)
-		dasp = append(dasp, ad)
+		dasp = append(dasp, getAnswerDisplay(ans, qz, qn))
 	}
 
 	c.V.RenderTemplate(w, "qm_answer.html", dasp)
@@ -278,33 +398,174 @@ func (c *Controller) GetAllAnswersForQuestion(w http.ResponseWriter, r *http.Req
 
 // SaveScores stores all the quizmaster awarded points to the answers
 func (c *Controller) SaveScores(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
 	vars := mux.Vars(r)
 	qnid, err := strconv.Atoi(vars["questionid"])
 	if view.Should500(err, w, "could not parse question id") {
 		return
 	}
-	sansa, err := c.P.GetAllAnswersToQuestionID(uint(qnid))
-	if view.Should500(err, w, "could not get answers to the question") {
+	qz, err := c.P.GetQuizFromQuestionID(uint(qnid))
+	if view.Should500(err, w, "could not find the quiz of the question") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(qz.GetId(), u), w, "no write privileges") {
+		return
+	}
+	scale, err := c.getPointScaleForQuestion(int64(qnid))
+	if view.Should500(err, w, "could not find the point scale") {
 		return
 	}
 	r.ParseForm()
-	obtainedScores, err := getIDToScoreMapFromPostForm(r.PostForm)
-	if view.Should500(err, w, "could not figure out the score assignment properly") {
+	obtainedScores, err := getIDToScoreMapFromPostForm(r.PostForm, scale)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not figure out the score assignment properly: %v", err)
 		return
 	}
+	if view.Should500(c.saveScoresForQuestion(int64(qnid), obtainedScores, u), w, "could not save the scores") {
+		return
+	}
+	fmt.Fprintln(w, "written")
+}
+
+// getPointScaleForQuestion looks up the point scale that applies to answers to the question.
+func (c *Controller) getPointScaleForQuestion(qnid int64) (*model.PointScale, error) {
+	qn, err := c.P.GetQuestionByID(uint(qnid))
+	if err != nil {
+		return nil, err
+	}
+	qz, err := c.P.GetQuizWithoutQuestions(qn.GetQuizId())
+	if err != nil {
+		return nil, err
+	}
+	return getPointScale(qz, qn), nil
+}
+
+// saveScoresForQuestion stores the points for the answers to a question, given as a map from
+// answer ID to points, as given by the quizmaster u.
+func (c *Controller) saveScoresForQuestion(qnid int64, scores map[int64]int64, u *model.User) error {
+	sansa, err := c.P.GetAllAnswersToQuestionID(uint(qnid))
+	if err != nil {
+		return err
+	}
 	answersToUpdate := make([]*model.Answer, 0)
 	for _, ans := range sansa {
-		if val, ok := obtainedScores[ans.GetId()]; ok {
-			if ans.GetPointsAwarded() != val {
+		if val, ok := scores[ans.GetId()]; ok {
+			// An answer that was not graded yet reads as 0 points, but a 0 from the quizmaster is a score.
+			if ans.PointsAwarded == nil || ans.GetPointsAwarded() != val {
 				ans.PointsAwarded = proto.Int64(val)
+				// Automatic grading must not undo what the quizmaster chose.
+				ans.PointsOverridden = proto.Bool(true)
 				answersToUpdate = append(answersToUpdate, ans)
 			}
 		}
 	}
-	if view.Should500(c.P.SaveMultipleAnswers(answersToUpdate), w, "could not save the scores") {
+	return c.P.SaveMultipleAnswers(answersToUpdate, u)
+}
+
+// QmAnswerFeed upgrades the connection to a WebSocket that pushes answers to the quizmaster
+// as participants submit them, and accepts scores from the quizmaster. With readonly=true,
+// as on the presenter view, it does not accept scores.
+func (c *Controller) QmAnswerFeed(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
 		return
 	}
-	fmt.Fprintln(w, "written")
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	readOnly := r.URL.Query().Get("readonly") == "true"
+	// Subscribe before the upgrade so that no answer is missed once the client is connected.
+	msgs, unsubscribe := c.answers.subscribe(int64(qzid))
+	defer unsubscribe()
+	conn, err := answerFeedUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with an error.
+		log.Printf("could not upgrade the answer feed: %v", err)
+		return
+	}
+	defer conn.Close()
+
+	// Replies go only to this connection. They are written from the loop below
+	// since a websocket connection allows a single writer at a time.
+	replies := make(chan *answerFeedMessage, 1)
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go func() {
+		defer close(done)
+		for {
+			var m answerFeedMessage
+			if err := conn.ReadJSON(&m); err != nil {
+				return
+			}
+			reply := &answerFeedMessage{Type: "error", Error: "this answer feed is read-only"}
+			if !readOnly {
+				reply = c.handleAnswerFeedMessage(int64(qzid), u, &m)
+			}
+			select {
+			case replies <- reply:
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(answerFeedPingInterval)
+	defer ping.Stop()
+	for {
+		var err error
+		select {
+		case <-done:
+			return
+		case b, ok := <-msgs:
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "too far behind"))
+				return
+			}
+			err = conn.WriteMessage(websocket.TextMessage, b)
+		case m := <-replies:
+			err = conn.WriteJSON(m)
+		case <-ping.C:
+			err = conn.WriteMessage(websocket.PingMessage, nil)
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func (c *Controller) handleAnswerFeedMessage(qzid int64, u *model.User, m *answerFeedMessage) *answerFeedMessage {
+	if m.Type != "scores" {
+		return &answerFeedMessage{Type: "error", Error: fmt.Sprintf("unexpected message type: %v", m.Type)}
+	}
+	qz, err := c.P.GetQuizFromQuestionID(uint(m.QuestionID))
+	if err != nil || qz.GetId() != qzid {
+		return &answerFeedMessage{Type: "error", Error: "could not find that question in this quiz"}
+	}
+	scale, err := c.getPointScaleForQuestion(m.QuestionID)
+	if err != nil {
+		return &answerFeedMessage{Type: "error", Error: "could not find the point scale"}
+	}
+	for _, pts := range m.Scores {
+		if err := checkScore(scale, pts); err != nil {
+			return &answerFeedMessage{Type: "error", Error: err.Error()}
+		}
+	}
+	if err := c.saveScoresForQuestion(m.QuestionID, m.Scores, u); err != nil {
+		log.Printf("could not save scores from the answer feed: %v", err)
+		return &answerFeedMessage{Type: "error", Error: "could not save the scores"}
+	}
+	return &answerFeedMessage{Type: "saved", QuestionID: m.QuestionID}
 }
 
 // UpdateQuizProperties stores the quiz properties
@@ -332,6 +593,31 @@ func (c *Controller) UpdateQuizProperties(w http.ResponseWriter, r *http.Request
 	qz.Id = proto.Int64(int64(qzid))
 	qz.Title = proto.String(r.PostForm["qz-title"][0])
 	qz.HtmlDescription = proto.String(r.PostForm["qz-descr"][0])
+	// The start time is optional, and is sent in seconds since the epoch so that
+	// the browser takes care of the quizmaster's time zone.
+	if st := r.PostForm.Get("qz-start-time"); st != "" {
+		t, err := strconv.ParseInt(st, 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, "could not parse the start time")
+			return
+		}
+		qz.ExpectedStartTime = proto.Int64(t)
+		qz.AutoStart = proto.Bool(r.PostForm.Get("qz-auto-start") == "true")
+	}
+	qz.SpeedBonus, err = getSpeedBonusFromPostForm(r.PostForm)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not parse the speed bonus: %v", err)
+		return
+	}
+	qz.PointScale, err = getPointScaleFromFormValues(r.PostForm, "qz-scale")
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not parse the point scale: %v", err)
+		return
+	}
+	qz.JokerEnabled = proto.Bool(r.PostForm.Get("qz-joker") == "true")
 	if view.Should500(c.P.SaveQuizMetadata(&qz), w, "could not save the quiz") {
 		return
 	}
@@ -378,7 +664,73 @@ func (c *Controller) ReinstateQuiz(w http.ResponseWriter, r *http.Request) {
 	view.WriteJSONString(w, fmt.Sprint(qzid))
 }
 
-func getIDToScoreMapFromPostForm(p url.Values) (map[int64]int64, error) {
+// answerDisplay is how an answer is shown to the quizmaster.
+type answerDisplay struct {
+	AnswerID            int64
+	SolverID            int64
+	SolverProfileName   string
+	AnswerDisplayText   string
+	ResponseTimeS       int64
+	PointsAwarded       int64
+	CustomPointsAwarded bool
+	// Presets are the score buttons, and MinPoints and MaxPoints the bounds, of the point scale.
+	Presets   []int64
+	MinPoints *int64
+	MaxPoints *int64
+	// Elapsed is how long after the question was activated the answer came in, if known.
+	Elapsed string
+	// Match is how a text answer matched the answer key: exact, fuzzy or none.
+	// It is empty if the answer was not graded against text.
+	Match string
+	// UploadURL is where the uploaded file can be seen, for upload answers.
+	UploadURL string
+	// Wager is the points bet on the answer, for wager questions.
+	Wager *int64
+}
+
+func getAnswerDisplay(ans *model.Answer, qz *model.Quiz, qn *model.Question) *answerDisplay {
+	var ad answerDisplay
+	scale := getPointScale(qz, qn)
+	ad.AnswerID = ans.GetId()
+	ad.SolverID = ans.GetSolverId()
+	for _, prf := range qz.GetParticipants() {
+		if ad.SolverID == int64(prf.GetUserId()) {
+			ad.SolverProfileName = prf.GetProfileName()
+			break
+		}
+	}
+	ad.AnswerDisplayText = getPrintableStringFromAnswer(ans)
+	if ans.GetType() == model.AnswerType_MATCHING_ANSWER {
+		// Item numbers alone are hard to check, so the pairs are spelled out.
+		ad.AnswerDisplayText = getAnswerLabel(qn, ans)
+	}
+	ad.UploadURL = getUploadURL(ans)
+	if qn.GetWager() {
+		ad.Wager = proto.Int64(ans.GetWager())
+	}
+	ad.ResponseTimeS = ans.GetResponseTimeS()
+	if ans.ElapsedMs != nil {
+		ad.Elapsed = fmt.Sprintf("%.1fs", float64(ans.GetElapsedMs())/1000)
+	}
+	ad.PointsAwarded = ans.GetPointsAwarded()
+	ad.Presets = getPresets(scale)
+	ad.MinPoints, ad.MaxPoints = scale.MinPoints, scale.MaxPoints
+	ad.CustomPointsAwarded = ans.PointsAwarded != nil && !slices.Contains(ad.Presets, ans.GetPointsAwarded())
+	switch ans.GetTextMatch() {
+	case model.TextMatch_EXACT_MATCH:
+		ad.Match = "exact"
+	case model.TextMatch_FUZZY_MATCH:
+		ad.Match = "fuzzy"
+	case model.TextMatch_NO_MATCH:
+		ad.Match = "none"
+	}
+	return &ad
+}
+
+// getIDToScoreMapFromPostForm reads the points for each answer from the grading form, and
+// checks that they are within the bounds of the point scale. The points for a group of answers,
+// grp-<id>-score, go to every answer listed in grp-<id>-answers.
+func getIDToScoreMapFromPostForm(p url.Values, scale *model.PointScale) (map[int64]int64, error) {
 	resp := make(map[int64]int64)
 	for k, v := range p {
 		if len(v) < 1 {
@@ -389,24 +741,22 @@ func getIDToScoreMapFromPostForm(p url.Values) (map[int64]int64, error) {
 			if err != nil {
 				return nil, err
 			}
-			if v[0] == "custom" {
-				cskey := fmt.Sprintf("ans-%v-custom-score", answerID)
-				if _, ok := p[cskey]; !ok {
-					return nil, fmt.Errorf("custom score option was chosen but value was not provided")
-				}
-				csval := p[cskey]
-				if len(csval) == 0 || len(csval[0]) == 0 {
-					return nil, fmt.Errorf("custom score option was chosen but value was empty")
-				}
-				score, err := strconv.ParseInt(csval[0], 10, 64)
-				if err != nil {
-					return nil, err
-				}
-				resp[answerID] = score
-			} else {
-				score, err := strconv.ParseInt(v[0], 10, 64)
+			score, err := getScoreFromPostForm(p, fmt.Sprintf("ans-%v", answerID), v[0], scale)
+			if err != nil {
+				return nil, err
+			}
+			resp[answerID] = score
+		}
+		if matches := groupScoreFormName.FindStringSubmatch(k); len(matches) > 1 {
+			prefix := fmt.Sprintf("grp-%v", matches[1])
+			score, err := getScoreFromPostForm(p, prefix, v[0], scale)
+			if err != nil {
+				return nil, err
+			}
+			for _, id := range strings.Split(p.Get(prefix+"-answers"), ",") {
+				answerID, err := strconv.ParseInt(id, 10, 64)
 				if err != nil {
-					return nil, err
+					return nil, fmt.Errorf("could not read the answers in group %v: %v", matches[1], err)
 				}
 				resp[answerID] = score
 			}
@@ -415,6 +765,27 @@ func getIDToScoreMapFromPostForm(p url.Values) (map[int64]int64, error) {
 	return resp, nil
 }
 
+// getScoreFromPostForm reads the score chosen with the radio buttons named <prefix>-score,
+// whose value is v, and the <prefix>-custom-score text box.
+func getScoreFromPostForm(p url.Values, prefix string, v string, scale *model.PointScale) (int64, error) {
+	if v == "custom" {
+		cskey := prefix + "-custom-score"
+		if _, ok := p[cskey]; !ok {
+			return 0, fmt.Errorf("custom score option was chosen but value was not provided")
+		}
+		csval := p[cskey]
+		if len(csval) == 0 || len(csval[0]) == 0 {
+			return 0, fmt.Errorf("custom score option was chosen but value was empty")
+		}
+		v = csval[0]
+	}
+	score, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return score, checkScore(scale, score)
+}
+
 func getPrintableStringFromAnswer(ans *model.Answer) string {
 	switch ans.GetType() {
 	case model.AnswerType_TEXT_ANSWER:
@@ -432,6 +803,41 @@ func getPrintableStringFromAnswer(ans *model.Answer) string {
 		return "False"
 	case model.AnswerType_MULTIPLE_CHOICE_ANSWER:
 		return fmt.Sprintf("Option %v", (ans.GetAnsChoiceIndex() + 1))
+	case model.AnswerType_MULTI_SELECT_ANSWER:
+		if len(ans.GetAnsChoiceIndices()) == 0 {
+			return "No options"
+		}
+		opts := make([]string, 0, len(ans.GetAnsChoiceIndices()))
+		for _, i := range ans.GetAnsChoiceIndices() {
+			opts = append(opts, strconv.FormatInt(i+1, 10))
+		}
+		return "Options " + strings.Join(opts, ", ")
+	case model.AnswerType_ORDERING_ANSWER:
+		items := make([]string, 0, len(ans.GetAnsOrder()))
+		for _, i := range ans.GetAnsOrder() {
+			items = append(items, strconv.FormatInt(i+1, 10))
+		}
+		return "Order " + strings.Join(items, ", ")
+	case model.AnswerType_MATCHING_ANSWER:
+		if len(ans.GetAnsPairs()) == 0 {
+			return "No pairs"
+		}
+		pairs := make([]string, 0, len(ans.GetAnsPairs()))
+		for _, pr := range ans.GetAnsPairs() {
+			pairs = append(pairs, fmt.Sprintf("%v-%v", pr.GetLeft()+1, getMatchLetter(pr.GetRight())))
+		}
+		return strings.Join(pairs, ", ")
+	case model.AnswerType_CLOZE_ANSWER:
+		blanks := make([]string, 0, len(ans.GetAnsBlanks()))
+		for _, b := range ans.GetAnsBlanks() {
+			if b = strings.TrimSpace(b); b == "" {
+				b = "___"
+			}
+			blanks = append(blanks, b)
+		}
+		return strings.Join(blanks, " / ")
+	case model.AnswerType_UPLOAD_ANSWER:
+		return fmt.Sprintf("Upload %v", ans.GetUploadId())
 	default:
 		return "Error: Invalid Answer"
 	}
@@ -16,7 +16,10 @@ package controller
 
 import (
 	"fmt"
+	"net/http"
 	"quizdrum/model"
+	"strconv"
+	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/proto"
@@ -62,6 +65,24 @@ func TestGetPrintableStringFromAnswer(t *testing.T) {
 			},
 			want: "Option 3",
 		},
+		{
+			ans: &model.Answer{
+				Type:             model.AnswerType_MULTI_SELECT_ANSWER.Enum(),
+				AnsChoiceIndices: []int64{0, 2},
+			},
+			want: "Options 1, 3",
+		},
+		{
+			ans:  &model.Answer{Type: model.AnswerType_MULTI_SELECT_ANSWER.Enum()},
+			want: "No options",
+		},
+		{
+			ans: &model.Answer{
+				Type:     model.AnswerType_ORDERING_ANSWER.Enum(),
+				AnsOrder: []int64{2, 0, 1},
+			},
+			want: "Order 3, 1, 2",
+		},
 		{
 			ans: &model.Answer{
 				Type:        model.AnswerType_LONG_TEXT_ANSWER.Enum(),
@@ -99,3 +120,45 @@ func TestGetPrintableStringFromAnswer(t *testing.T) {
 		})
 	}
 }
+
+func TestReorderQuestions(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	var qnids []string
+	for _, title := range []string{"One", "Two", "Three"} {
+		qnids = append(qnids, tq.addQuestion(t, c, "qn-title="+title+"&qn-body=Body&qn-type=text"))
+	}
+	reorder := func(cookie *http.Cookie, order ...string) savedHTTPResponse {
+		return callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/reorder", tq.qzid),
+			"qn-ids="+strings.Join(order, ","), cookie, map[string]string{"quizid": tq.qzid}, c.ReorderQuestions)
+	}
+
+	if r := reorder(tq.ppCookie, qnids[2], qnids[0], qnids[1]); r.statuscode != http.StatusUnauthorized {
+		t.Errorf("want: HTTP 401 for another user. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	for _, bad := range [][]string{{qnids[2], qnids[0]}, {qnids[2], qnids[0], qnids[0]}, {qnids[2], "x", qnids[1]}} {
+		if r := reorder(tq.qmCookie, bad...); r.statuscode != http.StatusBadRequest {
+			t.Errorf("reorder to %v: want HTTP 400, got HTTP %v. %v", bad, r.statuscode, r.resptext)
+		}
+	}
+	if r := reorder(tq.qmCookie, qnids[2], qnids[0], qnids[1]); r.statuscode != http.StatusOK {
+		t.Fatalf("could not reorder: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	r := callController("GET", fmt.Sprintf("/quizmaster/quiz/%v/live", tq.qzid), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.QmLive)
+	if want := fmt.Sprintf("var allQuestionIds = [ %v,%v,%v]", qnids[2], qnids[0], qnids[1]); !strings.Contains(r.resptext, want) {
+		t.Errorf("the live page does not follow the new order. want %q in:\n%v", want, r.resptext)
+	}
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuiz(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := getScoreboard(qz, nil)
+	if got, want := strings.Join(board.QuestionTitle, ","), "Three,One,Two"; got != want {
+		t.Errorf("wrong scoreboard columns. want %v, got %v", want, got)
+	}
+}
@@ -15,6 +15,7 @@
 package controller
 
 import (
+	"encoding/json"
 	"html/template"
 	"net/http"
 	"quizdrum/model"
@@ -49,9 +50,12 @@ func (c *Controller) QmEditQuiz(w http.ResponseWriter, r *http.Request) {
 	s := struct {
 		U *model.User
 		Q *model.Quiz
+		// NewRound fills the form to add a round.
+		NewRound *model.Round
 	}{
-		U: u,
-		Q: q,
+		U:        u,
+		Q:        q,
+		NewRound: &model.Round{},
 	}
 
 	c.V.RenderTemplate(w, "qm_editquiz.html", s)
@@ -93,21 +97,113 @@ func (c *Controller) QmLive(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	roundStarts, err := json.Marshal(getRoundStarts(q))
+	if view.Should500(err, w, "could not format the rounds") {
+		return
+	}
+
 	s := struct {
 		U           *model.User
 		Q           *model.Quiz
 		Qn          *model.Question
 		QuestionIds template.JS
+		RoundStarts template.JS
+		RoundCard   *model.Round
 	}{
 		U:           u,
 		Q:           q,
 		Qn:          qn,
 		QuestionIds: template.JS(getQuestionSequence(q)),
+		RoundStarts: template.JS("var roundStarts = " + string(roundStarts)),
+		RoundCard:   getRound(q, q.GetLiveRoundId()),
 	}
 
 	c.V.RenderTemplate(w, "qm_live.html", s)
 }
 
+// QmPresent renders a read-only view of the live quiz that is meant to be shown on a big screen.
+// It leaves out the answers and grading controls, and follows the live question by itself.
+func (c *Controller) QmPresent(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.RedirToLoginIfError(err, w, r) {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w,
+		"You do not have access to present this quiz. Please <a href='/logout'>Logout</a>"+
+			" and then log in again with an account that has access.") {
+		return
+	}
+	q, err := c.P.GetQuiz(int64(qzid))
+	if view.Should500(err, w, "could not fetch quiz") {
+		return
+	}
+	var qn *model.Question
+	for _, qni := range q.GetQuestions() {
+		if qni.GetId() == q.GetLiveQuestionId() {
+			qn = qni
+			break
+		}
+	}
+	answerIds := make([]string, 0)
+	var tally []answerTally
+	if qn != nil {
+		answers, err := c.P.GetAllAnswersToQuestionID(uint(qn.GetId()))
+		if view.Should500(err, w, "could not fetch answers") {
+			return
+		}
+		for _, ans := range answers {
+			answerIds = append(answerIds, strconv.FormatInt(ans.GetId(), 10))
+		}
+		if q.GetAnswerRevealed() {
+			tally = getAnswerTally(qn, answers, 0)
+		}
+	} else {
+		qn = &model.Question{
+			Id:       proto.Int64(0),
+			Title:    proto.String(q.GetTitle()),
+			HtmlBody: proto.String(q.GetHtmlDescription()),
+			Type:     model.AnswerType_UNKNOWN_ANSWER_TYPE.Enum(),
+		}
+	}
+
+	// The scoreboard is only computed when the quizmaster chooses to reveal it.
+	var board *scoreboard
+	if r.FormValue("scoreboard") == "1" {
+		ansmap, err := c.P.GetAllAnswersForSetOfQuestions(q.GetQuestions())
+		if view.Should500(err, w, "could not fetch answers") {
+			return
+		}
+		b := getScoreboard(q, ansmap)
+		b.sortByTotal()
+		board = &b
+	}
+
+	s := struct {
+		Q           *model.Quiz
+		Qn          *model.Question
+		AnswerCount int
+		AnswerIds   string
+		Tally       []answerTally
+		Scoreboard  *scoreboard
+		RoundCard   *model.Round
+	}{
+		Q:           q,
+		Qn:          qn,
+		AnswerCount: len(answerIds),
+		AnswerIds:   strings.Join(answerIds, ","),
+		Tally:       tally,
+		Scoreboard:  board,
+		RoundCard:   getRound(q, q.GetLiveRoundId()),
+	}
+
+	c.V.RenderTemplate(w, "qm_present.html", s)
+}
+
 func getQuestionSequence(q *model.Quiz) string {
 	qnids := make([]string, 0)
 	for _, qn := range q.GetQuestions() {
@@ -141,54 +237,17 @@ func (c *Controller) RenderQMScoreboard(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	// TODO: refactor this copy-pasted code from ppui.go
-	type participantAndScores struct {
-		ParticipantName string
-		Total           int64
-		Score           []int64
-	}
 	type scbd struct {
-		QuizName      string
-		QuestionTitle []string
-		PAndScore     []participantAndScores
-		U             *model.User
+		scoreboard
+		QuizName string
+		U        *model.User
 	}
 
 	var board scbd
+	board.scoreboard = getScoreboard(qz, ansmap)
+	board.sortByTotal()
 	board.QuizName = qz.GetTitle()
 	board.U = u
 
-	// First, we arrange the participants in some order
-	ppToIndex := make(map[int64]int)
-	for i, pp := range qz.GetParticipants() {
-		ppToIndex[pp.GetUserId()] = i
-	}
-
-	// Next, we arrange the questions in some order
-	// TODO: change this to depend on the question_sequence variable
-	qnToIndex := make(map[int64]int)
-	for i, qn := range qz.GetQuestions() {
-		qnToIndex[qn.GetId()] = i
-		board.QuestionTitle = append(board.QuestionTitle, qn.GetTitle())
-	}
-
-	// Now, we run through the participants and write down their names
-	board.PAndScore = make([]participantAndScores, len(ppToIndex))
-	for _, pp := range qz.GetParticipants() {
-		y := ppToIndex[pp.GetUserId()]
-		board.PAndScore[y].ParticipantName = pp.GetProfileName()
-		board.PAndScore[y].Score = make([]int64, len(qnToIndex))
-	}
-
-	// Now we fill out the score tables
-	for _, qn := range qz.GetQuestions() {
-		x := qnToIndex[qn.GetId()]
-		for _, ans := range ansmap[qn] {
-			y := ppToIndex[ans.GetSolverId()]
-			board.PAndScore[y].Score[x] = ans.GetPointsAwarded()
-			board.PAndScore[y].Total += ans.GetPointsAwarded()
-		}
-	}
-
 	c.V.RenderTemplate(w, "qm_scoreboard.html", board)
 }
@@ -18,7 +18,9 @@ import (
 	"fmt"
 	"net/url"
 	"quizdrum/model"
+	"slices"
 	"strconv"
+	"strings"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -43,6 +45,19 @@ func GetQuestionFromPostBody(p url.Values) (*model.Question, error) {
 	qn.Title = proto.String(p["qn-title"][0])
 	qn.HtmlBody = proto.String(p["qn-body"][0])
 	qn.QuizId = proto.Int64(int64(qid))
+	if val, ok := p["qn-solution"]; ok && val[0] != "" {
+		qn.HtmlSolution = proto.String(val[0])
+	}
+	if val, ok := p["qn-time-limit"]; ok && val[0] != "" {
+		tl, err := strconv.ParseInt(val[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if tl < 0 {
+			return nil, fmt.Errorf("the time limit cannot be negative: %v", tl)
+		}
+		qn.TimeLimitS = proto.Int64(tl)
+	}
 	switch p["qn-type"][0] {
 	case "text":
 		qn.Type = model.AnswerType_TEXT_ANSWER.Enum()
@@ -57,14 +72,317 @@ func GetQuestionFromPostBody(p url.Values) (*model.Question, error) {
 		if err = setMcqOptionsFromFormValues(p["mcq-opt"], &qn); err != nil {
 			return nil, err
 		}
+	case "msq":
+		qn.Type = model.AnswerType_MULTI_SELECT_ANSWER.Enum()
+		if err = setMcqOptionsFromFormValues(p["mcq-opt"], &qn); err != nil {
+			return nil, err
+		}
+	case "order":
+		qn.Type = model.AnswerType_ORDERING_ANSWER.Enum()
+		if err = setMcqOptionsFromFormValues(p["mcq-opt"], &qn); err != nil {
+			return nil, err
+		}
+	case "match":
+		qn.Type = model.AnswerType_MATCHING_ANSWER.Enum()
+		if err = setMcqOptionsFromFormValues(p["mcq-opt"], &qn); err != nil {
+			return nil, err
+		}
+		if err = setMatchChoicesFromFormValues(p.Get("qn-match-right"), &qn); err != nil {
+			return nil, err
+		}
+	case "cloze":
+		qn.Type = model.AnswerType_CLOZE_ANSWER.Enum()
+		if countBlanks(&qn) == 0 {
+			return nil, fmt.Errorf("A fill in the blanks question must mark its blanks with ___")
+		}
+	case "upload":
+		qn.Type = model.AnswerType_UPLOAD_ANSWER.Enum()
 	case "float":
 		qn.Type = model.AnswerType_FLOAT_ANSWER.Enum()
 	default:
 		return nil, fmt.Errorf("unexpected question type: %v", p["qn-type"][0])
 	}
+	if val, ok := p["qn-points"]; ok && val[0] != "" {
+		pts, err := strconv.ParseInt(val[0], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		qn.Points = proto.Int64(pts)
+	}
+	if rd := p.Get("qn-round"); rd != "" {
+		rdid, err := strconv.ParseInt(rd, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		qn.RoundId = proto.Int64(rdid)
+	}
+	if p.Get("qn-wager") == "true" {
+		qn.Wager = proto.Bool(true)
+	}
+	if qn.PointScale, err = getPointScaleFromFormValues(p, "qn-scale"); err != nil {
+		return nil, err
+	}
+	if qn.Points != nil && qn.PointScale != nil {
+		if err := checkScore(qn.PointScale, qn.GetPoints()); err != nil {
+			return nil, err
+		}
+	}
+	if err := setAnswerKeyFromFormValues(p, &qn); err != nil {
+		return nil, err
+	}
+	if p.Get("qn-tie-breaker") == "true" {
+		if err := checkTieBreaker(&qn); err != nil {
+			return nil, err
+		}
+		qn.TieBreaker = proto.Bool(true)
+	}
 	return &qn, nil
 }
 
+// setAnswerKeyFromFormValues reads the answer key for the type of the question, if one was given.
+func setAnswerKeyFromFormValues(p url.Values, qn *model.Question) error {
+	var key model.AnswerKey
+	switch qn.GetType() {
+	case model.AnswerType_TEXT_ANSWER:
+		key.AcceptedTexts = getLinesFromFormValue(p.Get("qn-key-text"))
+		key.Aliases = getLinesFromFormValue(p.Get("qn-key-aliases"))
+		if len(key.AcceptedTexts) == 0 && len(key.Aliases) == 0 {
+			return nil
+		}
+		if err := setTextMatchingFromFormValues(p, &key); err != nil {
+			return err
+		}
+	case model.AnswerType_INT64_ANSWER, model.AnswerType_FLOAT_ANSWER:
+		if err := setNumericAnswerKeyFromFormValues(p, qn.GetType(), &key); err != nil {
+			return err
+		}
+		if key.CorrectInt == nil && key.CorrectFloat == nil && key.RangeMin == nil {
+			if len(key.ToleranceBands) > 0 {
+				return fmt.Errorf("a tolerance needs a correct answer or range to be measured from")
+			}
+			return nil
+		}
+	case model.AnswerType_BOOL_ANSWER:
+		switch p.Get("qn-key-bool") {
+		case "true":
+			key.CorrectBool = proto.Bool(true)
+		case "false":
+			key.CorrectBool = proto.Bool(false)
+		default:
+			return nil
+		}
+	case model.AnswerType_MULTIPLE_CHOICE_ANSWER:
+		if p.Get("qn-key-choice") == "" {
+			return nil
+		}
+		// The quizmaster counts the options from 1.
+		v, err := strconv.ParseInt(p.Get("qn-key-choice"), 10, 64)
+		if err != nil {
+			return err
+		}
+		if v < 1 || v > int64(len(qn.GetChoices())) {
+			return fmt.Errorf("the correct option must be between 1 and %v, got %v", len(qn.GetChoices()), v)
+		}
+		key.CorrectChoiceIndex = proto.Int64(v - 1)
+	case model.AnswerType_MULTI_SELECT_ANSWER:
+		if strings.TrimSpace(p.Get("qn-key-choices")) == "" {
+			return nil
+		}
+		if err := setMultiSelectAnswerKeyFromFormValues(p, len(qn.GetChoices()), &key); err != nil {
+			return err
+		}
+	case model.AnswerType_ORDERING_ANSWER:
+		if strings.TrimSpace(p.Get("qn-key-order")) == "" {
+			return nil
+		}
+		if err := setOrderingAnswerKeyFromFormValues(p, len(qn.GetChoices()), &key); err != nil {
+			return err
+		}
+	case model.AnswerType_MATCHING_ANSWER:
+		if err := setMatchingAnswerKeyFromFormValues(p, qn, &key); err != nil {
+			return err
+		}
+		if len(key.CorrectPairs) == 0 {
+			return nil
+		}
+	case model.AnswerType_CLOZE_ANSWER:
+		if len(p["qn-blank-accept"]) == 0 {
+			return nil
+		}
+		if err := setClozeAnswerKeyFromFormValues(p, qn, &key); err != nil {
+			return err
+		}
+		if err := setTextMatchingFromFormValues(p, &key); err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	qn.AnswerKey = &key
+	return nil
+}
+
+// setNumericAnswerKeyFromFormValues reads the correct value, the correct range and the tolerance
+// bands of a numeric question. Each band is a row of qn-band-tol, qn-band-unit and qn-band-points.
+func setNumericAnswerKeyFromFormValues(p url.Values, t model.AnswerType, key *model.AnswerKey) error {
+	if t == model.AnswerType_INT64_ANSWER && p.Get("qn-key-int") != "" {
+		v, err := strconv.ParseInt(p.Get("qn-key-int"), 10, 64)
+		if err != nil {
+			return err
+		}
+		key.CorrectInt = proto.Int64(v)
+	}
+	if t == model.AnswerType_FLOAT_ANSWER && p.Get("qn-key-float") != "" {
+		v, err := strconv.ParseFloat(p.Get("qn-key-float"), 64)
+		if err != nil {
+			return err
+		}
+		key.CorrectFloat = proto.Float64(v)
+	}
+	if p.Get("qn-key-min") != "" || p.Get("qn-key-max") != "" {
+		lo, err := strconv.ParseFloat(p.Get("qn-key-min"), 64)
+		if err != nil {
+			return fmt.Errorf("the correct range needs both ends: %v", err)
+		}
+		hi, err := strconv.ParseFloat(p.Get("qn-key-max"), 64)
+		if err != nil {
+			return fmt.Errorf("the correct range needs both ends: %v", err)
+		}
+		if lo > hi {
+			return fmt.Errorf("the correct range starts after it ends: %v to %v", lo, hi)
+		}
+		key.RangeMin = proto.Float64(lo)
+		key.RangeMax = proto.Float64(hi)
+	}
+
+	tols, units, pcts := p["qn-band-tol"], p["qn-band-unit"], p["qn-band-points"]
+	if len(units) != len(tols) || len(pcts) != len(tols) {
+		return fmt.Errorf("every tolerance needs a unit and points")
+	}
+	for i, tol := range tols {
+		if tol == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(tol, 64)
+		if err != nil {
+			return err
+		}
+		if v < 0 {
+			return fmt.Errorf("a tolerance cannot be negative: %v", v)
+		}
+		pct, err := strconv.ParseInt(pcts[i], 10, 64)
+		if err != nil {
+			return err
+		}
+		if pct < 0 || pct > 100 {
+			return fmt.Errorf("the points for a tolerance must be between 0 and 100 percent, got %v", pct)
+		}
+		b := &model.ToleranceBand{PointsPercent: proto.Int64(pct)}
+		switch units[i] {
+		case "abs":
+			b.Absolute = proto.Float64(v)
+		case "pct":
+			b.RelativePercent = proto.Float64(v)
+		default:
+			return fmt.Errorf("unexpected tolerance unit: %v", units[i])
+		}
+		key.ToleranceBands = append(key.ToleranceBands, b)
+	}
+	return nil
+}
+
+// setMultiSelectAnswerKeyFromFormValues reads qn-key-choices, the comma separated numbers of the
+// correct options counting from 1, and qn-key-msq-grading, how partly right answers are graded.
+func setMultiSelectAnswerKeyFromFormValues(p url.Values, numChoices int, key *model.AnswerKey) error {
+	for _, v := range strings.Split(p.Get("qn-key-choices"), ",") {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+		i, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		if i < 1 || i > int64(numChoices) {
+			return fmt.Errorf("the correct options must be between 1 and %v, got %v", numChoices, i)
+		}
+		if !slices.Contains(key.CorrectChoiceIndices, i-1) {
+			key.CorrectChoiceIndices = append(key.CorrectChoiceIndices, i-1)
+		}
+	}
+	slices.Sort(key.CorrectChoiceIndices)
+	switch p.Get("qn-key-msq-grading") {
+	case "", "all":
+		key.MultiSelectGrading = model.MultiSelectGrading_ALL_OR_NOTHING.Enum()
+	case "per-option":
+		key.MultiSelectGrading = model.MultiSelectGrading_PER_CORRECT_OPTION.Enum()
+	case "penalty":
+		key.MultiSelectGrading = model.MultiSelectGrading_PENALTY_PER_WRONG_OPTION.Enum()
+	default:
+		return fmt.Errorf("unexpected grading for the options: %v", p.Get("qn-key-msq-grading"))
+	}
+	return nil
+}
+
+// setTextMatchingFromFormValues reads how closely text answers must match: qn-key-max-edits
+// and the qn-text-norm checkboxes.
+func setTextMatchingFromFormValues(p url.Values, key *model.AnswerKey) error {
+	if val := p.Get("qn-key-max-edits"); val != "" {
+		v, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return err
+		}
+		if v < 0 {
+			return fmt.Errorf("the number of edits cannot be negative: %v", v)
+		}
+		key.MaxEditDistance = proto.Int64(v)
+	}
+	key.Normalization = getTextNormalizationFromFormValues(p)
+	return nil
+}
+
+// getLinesFromFormValue splits a textarea into its non-empty lines.
+func getLinesFromFormValue(v string) []string {
+	var lines []string
+	for _, t := range strings.Split(v, "\n") {
+		if t = strings.TrimSpace(t); t != "" {
+			lines = append(lines, t)
+		}
+	}
+	return lines
+}
+
+// getTextNormalizationFromFormValues reads the qn-text-norm checkboxes. The form always sends
+// an empty qn-text-norm, so that unchecking all of them can be told apart from not sending them,
+// which keeps the default normalization.
+func getTextNormalizationFromFormValues(p url.Values) *model.TextNormalization {
+	vals, ok := p["qn-text-norm"]
+	if !ok {
+		return nil
+	}
+	n := model.TextNormalization{
+		IgnoreCase:        proto.Bool(false),
+		IgnoreWhitespace:  proto.Bool(false),
+		IgnorePunctuation: proto.Bool(false),
+		IgnoreDiacritics:  proto.Bool(false),
+		IgnoreArticles:    proto.Bool(false),
+	}
+	for _, v := range vals {
+		switch v {
+		case "case":
+			n.IgnoreCase = proto.Bool(true)
+		case "whitespace":
+			n.IgnoreWhitespace = proto.Bool(true)
+		case "punctuation":
+			n.IgnorePunctuation = proto.Bool(true)
+		case "diacritics":
+			n.IgnoreDiacritics = proto.Bool(true)
+		case "articles":
+			n.IgnoreArticles = proto.Bool(true)
+		}
+	}
+	return &n
+}
+
 func setMcqOptionsFromFormValues(f []string, qn *model.Question) error {
 	if len(f) == 0 {
 		return fmt.Errorf("A multiple choice question must have the options set")
@@ -0,0 +1,142 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"quizdrum/model"
+	"quizdrum/view"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// regradeChange is a change that regrading makes to the points of an answer.
+type regradeChange struct {
+	AnswerID      int64
+	QuestionTitle string
+	Participant   string
+	AnswerText    string
+	OldPoints     int64
+	NewPoints     int64
+	// Overridden is set if the quizmaster had scored the answer by hand.
+	Overridden bool
+}
+
+// regradeAnswers applies the answer key of the question to its answers. Answers the quizmaster
+// scored by hand are left alone, unless includeOverridden is set, in which case they go back to
+// being graded automatically. It returns the answers that changed, and the changes to their points.
+func regradeAnswers(qz *model.Quiz, qn *model.Question, sansa []*model.Answer,
+	includeOverridden bool) ([]*model.Answer, []regradeChange) {
+	if qn.GetAnswerKey() == nil {
+		return nil, nil
+	}
+	names := make(map[int64]string)
+	for _, pp := range qz.GetParticipants() {
+		names[pp.GetUserId()] = pp.GetProfileName()
+	}
+	var changed []*model.Answer
+	var changes []regradeChange
+	for _, ans := range sansa {
+		overridden := ans.GetPointsOverridden()
+		if overridden && !includeOverridden {
+			continue
+		}
+		if _, ok := gradeAnswer(qz, qn, ans); !ok {
+			continue
+		}
+		old := ans.GetPointsAwarded()
+		ans.PointsOverridden = nil
+		if !applyAnswerKey(qz, qn, ans) && !overridden {
+			continue
+		}
+		changed = append(changed, ans)
+		if ans.GetPointsAwarded() != old {
+			changes = append(changes, regradeChange{
+				AnswerID:      ans.GetId(),
+				QuestionTitle: qn.GetTitle(),
+				Participant:   names[ans.GetSolverId()],
+				AnswerText:    getAnswerLabel(qn, ans),
+				OldPoints:     old,
+				NewPoints:     ans.GetPointsAwarded(),
+				Overridden:    overridden,
+			})
+		}
+	}
+	return changed, changes
+}
+
+// RegradeAnswers applies the answer keys again to the answers of one question, given as qn-id,
+// or of the whole quiz. Scores set by hand are only regraded with include-overridden=true.
+// With dry-run=true, it shows the changes it would make without saving them.
+func (c *Controller) RegradeAnswers(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	qz, err := c.P.GetQuiz(int64(qzid))
+	if view.Should500(err, w, "could not fetch quiz") {
+		return
+	}
+	r.ParseForm()
+	qns := qz.GetQuestions()
+	if v := r.PostForm.Get("qn-id"); v != "" {
+		qns = nil
+		for _, qn := range qz.GetQuestions() {
+			if strconv.FormatInt(qn.GetId(), 10) == v {
+				qns = append(qns, qn)
+			}
+		}
+		if len(qns) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "question %q is not in this quiz", v)
+			return
+		}
+	}
+	includeOverridden := r.PostForm.Get("include-overridden") == "true"
+	dryRun := r.PostForm.Get("dry-run") == "true"
+
+	ansmap, err := c.P.GetAllAnswersForSetOfQuestions(qns)
+	if view.Should500(err, w, "could not fetch answers") {
+		return
+	}
+	var changed []*model.Answer
+	var changes []regradeChange
+	for _, qn := range qns {
+		a, ch := regradeAnswers(qz, qn, ansmap[qn], includeOverridden)
+		changed = append(changed, a...)
+		changes = append(changes, ch...)
+	}
+	if !dryRun && len(changed) > 0 {
+		if view.Should500(c.P.SaveMultipleAnswers(changed, u), w, "could not save the regraded answers") {
+			return
+		}
+	}
+
+	type regrade struct {
+		DryRun  bool
+		Changes []regradeChange
+	}
+	c.V.RenderTemplate(w, "qm_regrade.html", regrade{DryRun: dryRun, Changes: changes})
+}
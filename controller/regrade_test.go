@@ -0,0 +1,105 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestRegradeAnswers(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris")
+
+	submit := func(text string) int64 {
+		ppCookie := callController("POST", "/api/common/guest-login", "", nil, nil, c.HandleGuestLogin).cookie
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=%v", tq.qzid, tq.qnid, text), ppCookie, nil, c.SubmitAnswer)
+		ansid, err := strconv.ParseInt(r.resptext, 10, 64)
+		if err != nil {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		return ansid
+	}
+	points := func() map[int64]int64 {
+		id, _ := strconv.ParseInt(tq.qnid, 10, 64)
+		answers, err := p.GetAllAnswersToQuestionID(uint(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := make(map[int64]int64)
+		for _, ans := range answers {
+			m[ans.GetId()] = ans.GetPointsAwarded()
+		}
+		return m
+	}
+
+	right, rome, roma := submit("Paris"), submit("Rome"), submit("Roma")
+	vars := map[string]string{"questionid": tq.qnid}
+	callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=2", rome), tq.qmCookie, vars, c.SaveScores)
+	// The key was wrong, and is fixed while the question is still open.
+	r := callController("POST", "/api/quizmaster/question/update",
+		fmt.Sprintf("quiz-id=%v&qn-id=%v&qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Rome%%0ARoma",
+			tq.qzid, tq.qnid), tq.qmCookie, nil, c.UpdateQuestion)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to update the question. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	regrade := func(form string) savedHTTPResponse {
+		return callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/regrade", tq.qzid), form, tq.qmCookie,
+			map[string]string{"quizid": tq.qzid}, c.RegradeAnswers)
+	}
+	r = regrade(fmt.Sprintf("qn-id=%v&dry-run=true", tq.qnid))
+	if r.statuscode != 200 || !strings.Contains(r.resptext, "Roma") || strings.Contains(r.resptext, "Rome") {
+		t.Errorf("want a dry run listing all but the answer scored by hand. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if got := points(); got[right] != 4 || got[rome] != 2 || got[roma] != 0 {
+		t.Errorf("want no points changed by a dry run, got %v", got)
+	}
+
+	r = regrade("dry-run=false")
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to regrade. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if got := points(); got[right] != 0 || got[rome] != 2 || got[roma] != 4 {
+		t.Errorf("want the quiz regraded except for the score set by hand, got %v", got)
+	}
+
+	r = regrade(fmt.Sprintf("qn-id=%v&include-overridden=true&dry-run=false", tq.qnid))
+	if r.statuscode != 200 || !strings.Contains(r.resptext, "set by hand") {
+		t.Errorf("want the score set by hand regraded and marked. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	ans, err := p.GetAnswerByID(uint(rome))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ans.GetPointsAwarded() != 4 || ans.GetPointsOverridden() {
+		t.Errorf("want the answer graded automatically again, got %v", ans)
+	}
+
+	if r := regrade("qn-id=1000&dry-run=true"); r.statuscode != 400 {
+		t.Errorf("want: HTTP 400 for a question outside the quiz. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/regrade", tq.qzid), "dry-run=true", tq.ppCookie,
+		map[string]string{"quizid": tq.qzid}, c.RegradeAnswers)
+	if r.statuscode != 401 {
+		t.Errorf("want: HTTP 401 for a regrade by a participant. got: HTTP %v", r.statuscode)
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"quizdrum/model"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// answerTally is one row of the breakdown of how everyone answered a question.
+type answerTally struct {
+	Label   string
+	Count   int
+	Percent int
+	// Mine is set on the row with the answer of the participant viewing the breakdown.
+	Mine bool
+}
+
+// getAnswerTally counts the answers to the question. Multiple choice, multi-select and true or false
+// questions list every option in order. Other questions list the distinct answers,
+// most popular first. userID is the participant viewing the breakdown, or 0 for none.
+func getAnswerTally(qn *model.Question, answers []*model.Answer, userID int64) []answerTally {
+	var tally []answerTally
+	index := make(map[string]int)
+	// key is the printable form of the answers that are counted under the label.
+	add := func(label string, key string) {
+		index[tallyKey(key)] = len(tally)
+		tally = append(tally, answerTally{Label: label})
+	}
+	switch qn.GetType() {
+	case model.AnswerType_MULTIPLE_CHOICE_ANSWER:
+		for i, ch := range qn.GetChoices() {
+			add(ch.GetHtmlBody(), getPrintableStringFromAnswer(&model.Answer{
+				Type: qn.GetType().Enum(), AnsChoiceIndex: proto.Int64(int64(i))}))
+		}
+	case model.AnswerType_MULTI_SELECT_ANSWER:
+		// Each answer can pick several options, so the options are counted one by one.
+		for _, ch := range qn.GetChoices() {
+			tally = append(tally, answerTally{Label: ch.GetHtmlBody()})
+		}
+		for _, ans := range answers {
+			for _, i := range ans.GetAnsChoiceIndices() {
+				if i < 0 || i >= int64(len(tally)) {
+					continue
+				}
+				tally[i].Count++
+				if userID != 0 && ans.GetSolverId() == userID {
+					tally[i].Mine = true
+				}
+			}
+		}
+		for i := range tally {
+			if len(answers) > 0 {
+				tally[i].Percent = tally[i].Count * 100 / len(answers)
+			}
+		}
+		return tally
+	case model.AnswerType_BOOL_ANSWER:
+		add("True", "True")
+		add("False", "False")
+	}
+	ordered := len(tally) > 0
+
+	for _, ans := range answers {
+		label := getPrintableStringFromAnswer(ans)
+		i, ok := index[tallyKey(label)]
+		if !ok {
+			add(strings.TrimSpace(label), label)
+			i = len(tally) - 1
+		}
+		tally[i].Count++
+		if userID != 0 && ans.GetSolverId() == userID {
+			tally[i].Mine = true
+		}
+	}
+	for i := range tally {
+		if len(answers) > 0 {
+			tally[i].Percent = tally[i].Count * 100 / len(answers)
+		}
+	}
+	if !ordered {
+		sort.SliceStable(tally, func(i, j int) bool {
+			return tally[i].Count > tally[j].Count
+		})
+	}
+	return tally
+}
+
+// getAnswerLabel is how the answer is shown to the participant who gave it.
+// Unlike getPrintableStringFromAnswer, it shows the text of the chosen option.
+func getAnswerLabel(qn *model.Question, ans *model.Answer) string {
+	if ans.GetType() == model.AnswerType_MULTIPLE_CHOICE_ANSWER {
+		if i := ans.GetAnsChoiceIndex(); i >= 0 && i < int64(len(qn.GetChoices())) {
+			return qn.GetChoices()[i].GetHtmlBody()
+		}
+	}
+	if ans.GetType() == model.AnswerType_MATCHING_ANSWER {
+		if label, ok := getMatchPairsLabel(qn, ans); ok {
+			return label
+		}
+	}
+	if ans.GetType() == model.AnswerType_ORDERING_ANSWER && isPermutation(ans.GetAnsOrder(), len(qn.GetChoices())) {
+		var labels []string
+		for _, it := range getOrderItems(qn, ans) {
+			labels = append(labels, it.Body)
+		}
+		return strings.Join(labels, ", then ")
+	}
+	if ans.GetType() == model.AnswerType_MULTI_SELECT_ANSWER && len(ans.GetAnsChoiceIndices()) > 0 {
+		var labels []string
+		for _, i := range ans.GetAnsChoiceIndices() {
+			if i < 0 || i >= int64(len(qn.GetChoices())) {
+				return getPrintableStringFromAnswer(ans)
+			}
+			labels = append(labels, qn.GetChoices()[i].GetHtmlBody())
+		}
+		return strings.Join(labels, ", ")
+	}
+	return getPrintableStringFromAnswer(ans)
+}
+
+// tallyKey groups answers that differ only in case or surrounding space.
+func tallyKey(label string) string {
+	return strings.ToLower(strings.TrimSpace(label))
+}
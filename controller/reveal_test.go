@@ -0,0 +1,122 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetAnswerTally(t *testing.T) {
+	mcq := &model.Question{
+		Type:    model.AnswerType_MULTIPLE_CHOICE_ANSWER.Enum(),
+		Choices: []*model.AnswerChoice{{HtmlBody: proto.String("Red")}, {HtmlBody: proto.String("Blue")}},
+	}
+	choose := func(solver int64, i int64) *model.Answer {
+		return &model.Answer{SolverId: proto.Int64(solver), Type: mcq.GetType().Enum(), AnsChoiceIndex: proto.Int64(i)}
+	}
+	got := getAnswerTally(mcq, []*model.Answer{choose(1, 1), choose(2, 1), choose(3, 1), choose(4, 0)}, 4)
+	want := []answerTally{
+		{Label: "Red", Count: 1, Percent: 25, Mine: true},
+		{Label: "Blue", Count: 3, Percent: 75},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("multiple choice: got %+v, want %+v", got, want)
+	}
+
+	msq := &model.Question{
+		Type:    model.AnswerType_MULTI_SELECT_ANSWER.Enum(),
+		Choices: mcq.GetChoices(),
+	}
+	pick := func(solver int64, i ...int64) *model.Answer {
+		return &model.Answer{SolverId: proto.Int64(solver), Type: msq.GetType().Enum(), AnsChoiceIndices: i}
+	}
+	got = getAnswerTally(msq, []*model.Answer{pick(1, 0, 1), pick(2, 1), pick(3)}, 2)
+	want = []answerTally{
+		{Label: "Red", Count: 1, Percent: 33},
+		{Label: "Blue", Count: 2, Percent: 66, Mine: true},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("multi-select: got %+v, want %+v", got, want)
+	}
+
+	text := &model.Question{Type: model.AnswerType_TEXT_ANSWER.Enum()}
+	say := func(solver int64, s string) *model.Answer {
+		return &model.Answer{SolverId: proto.Int64(solver), Type: text.GetType().Enum(), AnsText: proto.String(s)}
+	}
+	got = getAnswerTally(text, []*model.Answer{say(1, "Paris"), say(2, "Rome"), say(3, "paris ")}, 0)
+	want = []answerTally{
+		{Label: "Paris", Count: 2, Percent: 66},
+		{Label: "Rome", Count: 1, Percent: 33},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("text: got %+v, want %+v", got, want)
+	}
+}
+
+func TestSetAnswerRevealed(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	vars := map[string]string{"quizid": tq.qzid}
+	reveal := func(rv string) savedHTTPResponse {
+		return callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/reveal", tq.qzid),
+			"rv="+rv, tq.qmCookie, vars, c.SetAnswerRevealed)
+	}
+
+	if r := reveal("true"); r.statuscode != 409 {
+		t.Errorf("want: HTTP 409 when no question is live. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/reveal", tq.qzid),
+		"rv=true", tq.ppCookie, vars, c.SetAnswerRevealed)
+	if r.statuscode != 401 {
+		t.Errorf("want: HTTP 401 for a participant. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	qnid := tq.addQuestion(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-solution=Paris")
+	tq.setActive(t, c, qnid)
+	if r := reveal("true"); r.statuscode != 200 {
+		t.Fatalf("Failed to reveal the answer. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuizWithoutQuestions(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !qz.GetAnswerRevealed() || qz.GetAcceptingResponses() {
+		t.Errorf("want the answer revealed and responses closed, got %v", qz)
+	}
+	r = callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Paris", tq.qzid, qnid), tq.ppCookie, nil, c.SubmitAnswer)
+	if r.statuscode != 409 {
+		t.Errorf("want: HTTP 409 after the reveal. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	// Accepting responses again hides the answer.
+	callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/setacceptingresponses", tq.qzid),
+		"ar=true", tq.qmCookie, vars, c.SetAcceptingResponses)
+	if qz, err = p.GetQuizWithoutQuestions(id); err != nil {
+		t.Fatal(err)
+	}
+	if qz.GetAnswerRevealed() {
+		t.Errorf("the answer is still revealed after accepting responses again")
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"quizdrum/model"
+	"quizdrum/view"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+)
+
+// getRound returns the round of the quiz with the given ID, or nil if there is none.
+func getRound(qz *model.Quiz, rdid int64) *model.Round {
+	if rdid == 0 {
+		return nil
+	}
+	for _, rd := range qz.GetRounds() {
+		if rd.GetId() == rdid {
+			return rd
+		}
+	}
+	return nil
+}
+
+// getRoundFromPostForm reads a round from the rd- fields of the form. The ID is not set.
+func getRoundFromPostForm(p url.Values) (*model.Round, error) {
+	rd := model.Round{Title: proto.String(strings.TrimSpace(p.Get("rd-title")))}
+	if rd.GetTitle() == "" {
+		return nil, errors.New("the round needs a title")
+	}
+	if intro := p.Get("rd-intro"); intro != "" {
+		rd.HtmlIntro = proto.String(intro)
+	}
+	scale, err := getPointScaleFromFormValues(p, "rd-scale")
+	if err != nil {
+		return nil, fmt.Errorf("could not parse the point scale: %v", err)
+	}
+	rd.PointScale = scale
+	return &rd, nil
+}
+
+// getRoundStarts maps the questions that start a round to the round. A round starts
+// wherever the round changes from one question to the next, and its title card is shown
+// before that question. The questions of the quiz must be populated.
+func getRoundStarts(qz *model.Quiz) map[int64]int64 {
+	starts := make(map[int64]int64)
+	var prev int64
+	for _, qn := range qz.GetQuestions() {
+		rdid := qn.GetRoundId()
+		if getRound(qz, rdid) == nil {
+			rdid = 0
+		}
+		if rdid != 0 && rdid != prev {
+			starts[qn.GetId()] = rdid
+		}
+		prev = rdid
+	}
+	return starts
+}
+
+// validateRound returns model.ErrRoundNotFound if the question is in a round that its quiz does not have.
+func (c *Controller) validateRound(qn *model.Question) error {
+	if qn.GetRoundId() == 0 {
+		return nil
+	}
+	qz, err := c.P.GetQuizWithoutQuestions(qn.GetQuizId())
+	if err != nil {
+		return err
+	}
+	if getRound(qz, qn.GetRoundId()) == nil {
+		return model.ErrRoundNotFound
+	}
+	return nil
+}
+
+// showRoundCard puts up the title card of the round in the live quiz.
+// The live question stops accepting responses while the title card is shown.
+func showRoundCard(qz *model.Quiz, rd *model.Round) {
+	qz.LiveRoundId = proto.Int64(rd.GetId())
+	qz.AcceptingResponses = proto.Bool(false)
+	qz.AnswerRevealed = proto.Bool(false)
+	qz.ResponseDeadlineMs = nil
+}
+
+// NewRound is the API handler that adds a round to the end of the quiz.
+func (c *Controller) NewRound(w http.ResponseWriter, r *http.Request) {
+	c.saveRound(w, r, false)
+}
+
+// UpdateRound is the API handler that changes the title, intro or point scale of a round.
+func (c *Controller) UpdateRound(w http.ResponseWriter, r *http.Request) {
+	c.saveRound(w, r, true)
+}
+
+// saveRound stores the round in the form. If update is set, it replaces the round named in the URL.
+func (c *Controller) saveRound(w http.ResponseWriter, r *http.Request, update bool) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	r.ParseForm()
+	rd, err := getRoundFromPostForm(r.PostForm)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	}
+	if update {
+		rdid, err := strconv.ParseInt(vars["roundid"], 10, 64)
+		if view.Should500(err, w, "could not parse round id") {
+			return
+		}
+		rd.Id = proto.Int64(rdid)
+	}
+	rdid, err := c.P.SaveRound(int64(qzid), rd)
+	if errors.Is(err, model.ErrRoundNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not save the round") {
+		return
+	}
+	view.WriteJSONString(w, fmt.Sprint(rdid))
+}
+
+// DeleteRound is the API handler that removes a round from the quiz. Its questions are kept.
+func (c *Controller) DeleteRound(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	rdid, err := strconv.ParseInt(vars["roundid"], 10, 64)
+	if view.Should500(err, w, "could not parse round id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	if err := c.P.DeleteRound(int64(qzid), rdid); errors.Is(err, model.ErrRoundNotFound) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", err)
+		return
+	} else if view.Should500(err, w, "could not delete the round") {
+		return
+	}
+	fmt.Fprintln(w, "deleted")
+}
+
+// ShowRound is the API handler that shows the title card of a round during a live quiz.
+func (c *Controller) ShowRound(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	qzid, err := strconv.Atoi(vars["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	rdid, err := strconv.ParseInt(vars["roundid"], 10, 64)
+	if view.Should500(err, w, "could not parse round id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w, "no write privileges") {
+		return
+	}
+	qz, err := c.P.GetQuizWithoutQuestions(int64(qzid))
+	if view.Should500(err, w, "could not load quiz") {
+		return
+	}
+	rd := getRound(qz, rdid)
+	if rd == nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "%v", model.ErrRoundNotFound)
+		return
+	}
+	showRoundCard(qz, rd)
+	if view.Should500(c.P.SaveQuiz(qz), w, "could not save quiz") {
+		return
+	}
+	fmt.Fprintln(w, "Saved")
+}
@@ -0,0 +1,246 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestScoreboardRounds(t *testing.T) {
+	q1 := &model.Question{Id: proto.Int64(1), Title: proto.String("One"), RoundId: proto.Int64(7)}
+	q2 := &model.Question{Id: proto.Int64(2), Title: proto.String("Two"), RoundId: proto.Int64(7)}
+	q3 := &model.Question{Id: proto.Int64(3), Title: proto.String("Three"), RoundId: proto.Int64(5)}
+	q4 := &model.Question{Id: proto.Int64(4), Title: proto.String("Loose")}
+	qz := &model.Quiz{
+		Questions: []*model.Question{q1, q2, q3, q4},
+		// The round without questions is left off, and the rounds are in the order they are played.
+		Rounds: []*model.Round{
+			{Id: proto.Int64(5), Title: proto.String("Music")},
+			{Id: proto.Int64(6), Title: proto.String("Empty")},
+			{Id: proto.Int64(7), Title: proto.String("Sport")},
+		},
+		Participants: []*model.ParticipantProfile{
+			{UserId: proto.Int64(10), ProfileName: proto.String("Ann")},
+			{UserId: proto.Int64(20), ProfileName: proto.String("Bob")},
+			{UserId: proto.Int64(30), ProfileName: proto.String("Cat"), JokerRoundId: proto.Int64(5)},
+		},
+	}
+	ansmap := map[*model.Question][]*model.Answer{
+		q1: {
+			{SolverId: proto.Int64(10), PointsAwarded: proto.Int64(5)},
+			{SolverId: proto.Int64(20), PointsAwarded: proto.Int64(3)},
+		},
+		q2: {
+			{SolverId: proto.Int64(20), PointsAwarded: proto.Int64(2)},
+		},
+		q3: {
+			{SolverId: proto.Int64(10), PointsAwarded: proto.Int64(4)},
+			{SolverId: proto.Int64(30), PointsAwarded: proto.Int64(2)},
+		},
+		q4: {
+			{SolverId: proto.Int64(30), PointsAwarded: proto.Int64(10)},
+		},
+	}
+
+	board := getScoreboard(qz, ansmap)
+	if want := []string{"Music", "Sport"}; !reflect.DeepEqual(board.RoundTitle, want) {
+		t.Errorf("wrong rounds. want %v, got %v", want, board.RoundTitle)
+	}
+	wantTotals := map[string][]int64{"Ann": {4, 5}, "Bob": {0, 5}, "Cat": {4, 0}}
+	for _, ps := range board.PAndScore {
+		if !reflect.DeepEqual(ps.RoundTotal, wantTotals[ps.ParticipantName]) {
+			t.Errorf("wrong subtotals for %v. want %v, got %v", ps.ParticipantName, wantTotals[ps.ParticipantName], ps.RoundTotal)
+		}
+	}
+	wantWinners := []roundWinner{
+		{RoundTitle: "Music", Names: []string{"Ann", "Cat"}, Points: 4},
+		{RoundTitle: "Sport", Names: []string{"Ann", "Bob"}, Points: 5},
+	}
+	if !reflect.DeepEqual(board.RoundWinners, wantWinners) {
+		t.Errorf("wrong round winners. want %+v, got %+v", wantWinners, board.RoundWinners)
+	}
+}
+
+func TestRoundPointScale(t *testing.T) {
+	quizScale := &model.PointScale{WrongAnswerPoints: proto.Int64(-1)}
+	roundScale := &model.PointScale{WrongAnswerPoints: proto.Int64(-2)}
+	qnScale := &model.PointScale{WrongAnswerPoints: proto.Int64(-3)}
+	qz := &model.Quiz{
+		PointScale: quizScale,
+		Rounds: []*model.Round{
+			{Id: proto.Int64(1), PointScale: roundScale},
+			{Id: proto.Int64(2)},
+		},
+	}
+	testCases := []struct {
+		qn   *model.Question
+		want *model.PointScale
+	}{
+		{qn: &model.Question{RoundId: proto.Int64(1), PointScale: qnScale}, want: qnScale},
+		{qn: &model.Question{RoundId: proto.Int64(1)}, want: roundScale},
+		{qn: &model.Question{RoundId: proto.Int64(2)}, want: quizScale},
+		{qn: &model.Question{RoundId: proto.Int64(3)}, want: quizScale},
+		{qn: &model.Question{}, want: quizScale},
+		{qn: nil, want: quizScale},
+	}
+	for _, tc := range testCases {
+		if got := getPointScale(qz, tc.qn); got != tc.want {
+			t.Errorf("question %v: want %v, got %v", tc.qn, tc.want, got)
+		}
+	}
+}
+
+func TestGetRoundStarts(t *testing.T) {
+	qz := &model.Quiz{
+		Rounds: []*model.Round{{Id: proto.Int64(1)}, {Id: proto.Int64(2)}},
+	}
+	for i, rdid := range []int64{0, 1, 1, 2, 9, 2, 1} {
+		qz.Questions = append(qz.Questions, &model.Question{Id: proto.Int64(int64(i + 1)), RoundId: proto.Int64(rdid)})
+	}
+	// Questions in a round that the quiz does not have are not in any round.
+	want := map[int64]int64{2: 1, 4: 2, 6: 2, 7: 1}
+	if got := getRoundStarts(qz); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestRounds(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	newRound := func(body string) savedHTTPResponse {
+		return callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/round/new", tq.qzid), body,
+			tq.qmCookie, map[string]string{"quizid": tq.qzid}, c.NewRound)
+	}
+	if r := newRound("rd-intro=No+title"); r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a round without a title. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := newRound("rd-title=Bad&rd-scale-min=5&rd-scale-max=1"); r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a bad point scale. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	music := strings.Trim(newRound("rd-title=Music&rd-intro=Name+that+tune").resptext, "\"\n")
+	sport := strings.Trim(newRound("rd-title=Sport&rd-scale-presets=0,2,4").resptext, "\"\n")
+	if music != "1" || sport != "2" {
+		t.Fatalf("want rounds 1 and 2, got %q and %q", music, sport)
+	}
+	r := callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/round/%v/update", tq.qzid, music),
+		"rd-title=Music+Round&rd-intro=Name+that+tune", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid, "roundid": music}, c.UpdateRound)
+	if r.statuscode != http.StatusOK {
+		t.Errorf("could not update the round: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/round/9/update", tq.qzid),
+		"rd-title=Nowhere", tq.qmCookie, map[string]string{"quizid": tq.qzid, "roundid": "9"}, c.UpdateRound)
+	if r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a missing round. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	r = callController("GET", fmt.Sprintf("/quizmaster/quiz/%v/edit", tq.qzid), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.QmEditQuiz)
+	for _, want := range []string{`<option value="1">Music Round</option>`, `name="rd-scale-presets" placeholder="0, 5, 10" size="10"
+        value="0, 2, 4"`, "Add Round"} {
+		if !strings.Contains(r.resptext, want) {
+			t.Errorf("want %q on the editor:\n%v", want, r.resptext)
+		}
+	}
+
+	r = callController("POST", "/api/quizmaster/question/new",
+		fmt.Sprintf("quiz-id=%v&qn-title=Lost&qn-body=Body&qn-type=text&qn-round=9", tq.qzid), tq.qmCookie, nil, c.NewQuestion)
+	if r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a question in a missing round. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	q1 := tq.addQuestion(t, c, "qn-title=One&qn-body=Body&qn-type=text&qn-round="+music)
+	q2 := tq.addQuestion(t, c, "qn-title=Two&qn-body=Body&qn-type=text&qn-round="+sport)
+
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuiz(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := getPointScale(qz, qz.GetQuestions()[1]).GetPresets(); !reflect.DeepEqual(got, []int64{0, 2, 4}) {
+		t.Errorf("the question does not use the point scale of its round. got presets %v", got)
+	}
+
+	// The title card of the next round closes responses to the live question.
+	tq.setActive(t, c, q1)
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/showround/%v", tq.qzid, sport), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid, "roundid": sport}, c.ShowRound)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not show the round: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	qz, err = p.GetQuiz(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q1id, _ := strconv.ParseInt(q1, 10, 64)
+	if got, want := getQuizStatus(qz, time.Now()), (quizStatus{QuestionID: q1id, RoundID: 2}); got != want {
+		t.Errorf("wrong status with the title card up. want %+v, got %+v", want, got)
+	}
+	r = callController("GET", fmt.Sprintf("/quizmaster/quiz/%v/live", tq.qzid), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.QmLive)
+	for _, want := range []string{"Sport</h2>", fmt.Sprintf(`var roundStarts = {"%v":1,"%v":2}`, q1, q2)} {
+		if !strings.Contains(r.resptext, want) {
+			t.Errorf("want %q on the live page:\n%v", want, r.resptext)
+		}
+	}
+	r = callController("GET", fmt.Sprintf("/quizmaster/quiz/%v/present", tq.qzid), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.QmPresent)
+	if !strings.Contains(r.resptext, "Sport\n") || strings.Contains(r.resptext, "answers in.") {
+		t.Errorf("want the title card instead of the question on the presenter view:\n%v", r.resptext)
+	}
+	tq.setActive(t, c, q2)
+	qz, err = p.GetQuizWithoutQuestions(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qz.GetLiveRoundId() != 0 {
+		t.Errorf("the title card is still up after the next question was activated")
+	}
+
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Owls", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+	r = callController("GET", fmt.Sprintf("/quizmaster/quiz/%v/scoreboard", tq.qzid), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid}, c.RenderQMScoreboard)
+	if !strings.Contains(r.resptext, `scope="col">Music Round</th>`) || !strings.Contains(r.resptext, "Round winners") {
+		t.Errorf("want the round subtotals and winners on the scoreboard: HTTP %v\n%v", r.statuscode, r.resptext)
+	}
+
+	// The questions of a deleted round are in no round, and a new round does not pick them up.
+	r = callController("DELETE", fmt.Sprintf("/api/quizmaster/quiz/%v/round/%v/delete", tq.qzid, sport), "", tq.qmCookie,
+		map[string]string{"quizid": tq.qzid, "roundid": sport}, c.DeleteRound)
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("could not delete the round: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if again := strings.Trim(newRound("rd-title=Again").resptext, "\"\n"); again == sport {
+		t.Errorf("want a new round ID, got the ID %v of the deleted round again", again)
+	}
+	if qz, err = p.GetQuiz(id); err != nil {
+		t.Fatal(err)
+	}
+	q2id, _ := strconv.ParseInt(q2, 10, 64)
+	if starts := getRoundStarts(qz); len(starts) != 1 || starts[q2id] != 0 {
+		t.Errorf("want only the round of the first question to start, got %v", starts)
+	}
+}
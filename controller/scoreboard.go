@@ -0,0 +1,188 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"quizdrum/model"
+	"sort"
+)
+
+// participantAndScores is a row of the scoreboard.
+type participantAndScores struct {
+	ParticipantID   int64
+	ParticipantName string
+	Total           int64
+	Score           []int64
+	// RoundTotal is the subtotal for each round of the scoreboard.
+	RoundTotal []int64
+	// tieBreaks are the answers to the tie-breaker questions, which break ties on Total.
+	tieBreaks []tieBreakAnswer
+}
+
+// scoreboard holds the points of every participant on every question of a quiz.
+// Tie-breaker questions do not score points, so they are left out.
+type scoreboard struct {
+	QuestionID    []int64
+	QuestionTitle []string
+	// RoundTitle names the rounds that have questions on the scoreboard, in the order they are played.
+	RoundTitle   []string
+	RoundWinners []roundWinner
+	PAndScore    []participantAndScores
+}
+
+// roundWinner is whoever scored the most points in a round. Ties share the win.
+type roundWinner struct {
+	RoundTitle string
+	Names      []string
+	Points     int64
+}
+
+// getScoreboard arranges the answers to the quiz into a scoreboard.
+// The participants are in the order they registered.
+func getScoreboard(qz *model.Quiz, ansmap map[*model.Question][]*model.Answer) scoreboard {
+	var board scoreboard
+
+	// First, we arrange the participants in some order
+	ppToIndex := make(map[int64]int)
+	jokers := make(map[int64]int64)
+	for i, pp := range qz.GetParticipants() {
+		ppToIndex[pp.GetUserId()] = i
+		jokers[pp.GetUserId()] = pp.GetJokerRoundId()
+	}
+
+	// Next, we arrange the questions in the order they are asked
+	qnToIndex := make(map[int64]int)
+	var tieBreakers []*model.Question
+	for _, qn := range qz.GetQuestions() {
+		if qn.GetTieBreaker() {
+			tieBreakers = append(tieBreakers, qn)
+			continue
+		}
+		qnToIndex[qn.GetId()] = len(board.QuestionID)
+		board.QuestionID = append(board.QuestionID, qn.GetId())
+		board.QuestionTitle = append(board.QuestionTitle, qn.GetTitle())
+	}
+
+	// The rounds that have questions on the scoreboard get a subtotal each
+	qnToRound := make(map[int64]int)
+	for _, rd := range qz.GetRounds() {
+		found := false
+		for _, qn := range qz.GetQuestions() {
+			if _, ok := qnToIndex[qn.GetId()]; ok && qn.GetRoundId() == rd.GetId() {
+				qnToRound[qn.GetId()] = len(board.RoundTitle)
+				found = true
+			}
+		}
+		if found {
+			board.RoundTitle = append(board.RoundTitle, rd.GetTitle())
+		}
+	}
+
+	// Now, we run through the participants and write down their names
+	board.PAndScore = make([]participantAndScores, len(ppToIndex))
+	for _, pp := range qz.GetParticipants() {
+		y := ppToIndex[pp.GetUserId()]
+		board.PAndScore[y].ParticipantID = pp.GetUserId()
+		board.PAndScore[y].ParticipantName = pp.GetProfileName()
+		board.PAndScore[y].Score = make([]int64, len(qnToIndex))
+		if len(board.RoundTitle) > 0 {
+			board.PAndScore[y].RoundTotal = make([]int64, len(board.RoundTitle))
+		}
+		if len(tieBreakers) > 0 {
+			board.PAndScore[y].tieBreaks = make([]tieBreakAnswer, len(tieBreakers))
+		}
+	}
+
+	for i, qn := range tieBreakers {
+		for _, ans := range ansmap[qn] {
+			y := ppToIndex[ans.GetSolverId()]
+			board.PAndScore[y].tieBreaks[i] = getTieBreakAnswer(qn, ans)
+		}
+	}
+
+	// Now we fill out the score tables
+	for _, qn := range qz.GetQuestions() {
+		x, ok := qnToIndex[qn.GetId()]
+		if !ok {
+			continue
+		}
+		for _, ans := range ansmap[qn] {
+			y := ppToIndex[ans.GetSolverId()]
+			pts := getAnswerScore(qz, qn, ans)
+			// The joker doubles the points in its round, and so the subtotal of the round.
+			if rdid := jokers[ans.GetSolverId()]; rdid != 0 && rdid == qn.GetRoundId() {
+				pts *= 2
+			}
+			board.PAndScore[y].Score[x] = pts
+			board.PAndScore[y].Total += pts
+			if rx, ok := qnToRound[qn.GetId()]; ok {
+				board.PAndScore[y].RoundTotal[rx] += pts
+			}
+		}
+	}
+	board.RoundWinners = getRoundWinners(&board)
+	return board
+}
+
+// getRoundWinners finds who scored the most points in each round of the scoreboard.
+func getRoundWinners(b *scoreboard) []roundWinner {
+	if len(b.PAndScore) == 0 {
+		return nil
+	}
+	var winners []roundWinner
+	for x, title := range b.RoundTitle {
+		rw := roundWinner{RoundTitle: title, Points: b.PAndScore[0].RoundTotal[x]}
+		for _, ps := range b.PAndScore {
+			if pts := ps.RoundTotal[x]; pts > rw.Points {
+				rw.Points = pts
+				rw.Names = []string{ps.ParticipantName}
+			} else if pts == rw.Points {
+				rw.Names = append(rw.Names, ps.ParticipantName)
+			}
+		}
+		winners = append(winners, rw)
+	}
+	return winners
+}
+
+// getAnswerScore is what the answer adds to the total of the participant: its points and speed
+// bonus, or for a wager question, the wager won or lost. A wager is neither until it is graded.
+func getAnswerScore(qz *model.Quiz, qn *model.Question, ans *model.Answer) int64 {
+	if qn.GetTieBreaker() {
+		return 0
+	}
+	if qn.GetWager() {
+		if ans == nil || ans.PointsAwarded == nil {
+			return 0
+		}
+		if ans.GetPointsAwarded() > 0 {
+			return ans.GetWager()
+		}
+		return -ans.GetWager()
+	}
+	return ans.GetPointsAwarded() + getSpeedBonus(qz, qn, ans)
+}
+
+// sortByTotal ranks the participants with the most points first. Ties are broken by the
+// tie-breaker questions, if any.
+func (b *scoreboard) sortByTotal() {
+	sort.SliceStable(b.PAndScore, func(i, j int) bool {
+		x, y := b.PAndScore[i], b.PAndScore[j]
+		if x.Total != y.Total {
+			return x.Total > y.Total
+		}
+		return compareTieBreaks(x.tieBreaks, y.tieBreaks) < 0
+	})
+}
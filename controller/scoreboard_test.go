@@ -0,0 +1,62 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"quizdrum/model"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetScoreboard(t *testing.T) {
+	q1 := &model.Question{Id: proto.Int64(1), Title: proto.String("One")}
+	q2 := &model.Question{Id: proto.Int64(2), Title: proto.String("Two")}
+	qz := &model.Quiz{
+		Questions: []*model.Question{q1, q2},
+		Participants: []*model.ParticipantProfile{
+			{UserId: proto.Int64(10), ProfileName: proto.String("Ann")},
+			{UserId: proto.Int64(20), ProfileName: proto.String("Bob")},
+		},
+	}
+	ansmap := map[*model.Question][]*model.Answer{
+		q1: {
+			{SolverId: proto.Int64(10), PointsAwarded: proto.Int64(1)},
+			{SolverId: proto.Int64(20), PointsAwarded: proto.Int64(3)},
+		},
+		q2: {
+			{SolverId: proto.Int64(20), PointsAwarded: proto.Int64(2)},
+		},
+	}
+
+	board := getScoreboard(qz, ansmap)
+	want := scoreboard{
+		QuestionID:    []int64{1, 2},
+		QuestionTitle: []string{"One", "Two"},
+		PAndScore: []participantAndScores{
+			{ParticipantID: 10, ParticipantName: "Ann", Total: 1, Score: []int64{1, 0}},
+			{ParticipantID: 20, ParticipantName: "Bob", Total: 5, Score: []int64{3, 2}},
+		},
+	}
+	if !reflect.DeepEqual(board, want) {
+		t.Errorf("got %+v, want %+v", board, want)
+	}
+
+	board.sortByTotal()
+	if board.PAndScore[0].ParticipantName != "Bob" {
+		t.Errorf("want the leader first, got %+v", board.PAndScore)
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"quizdrum/model"
+	"quizdrum/view"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// scoreLogRow is a change to the points of an answer, as shown to the quizmaster.
+type scoreLogRow struct {
+	When          string
+	QuestionID    int64
+	QuestionTitle string
+	ParticipantID int64
+	Participant   string
+	OldPoints     int64
+	NewPoints     int64
+	ChangedBy     string
+}
+
+// getScoreLogRows names the questions, participants and quizmasters in the score changes.
+func getScoreLogRows(qz *model.Quiz, changes []*model.ScoreChange, loc *time.Location) []scoreLogRow {
+	titles := make(map[int64]string)
+	for _, qn := range qz.GetQuestions() {
+		titles[qn.GetId()] = qn.GetTitle()
+	}
+	names := make(map[int64]string)
+	for _, pp := range qz.GetParticipants() {
+		names[pp.GetUserId()] = pp.GetProfileName()
+	}
+	rows := make([]scoreLogRow, 0, len(changes))
+	for _, sc := range changes {
+		row := scoreLogRow{
+			When:          time.UnixMilli(sc.GetChangedAtMs()).In(loc).Format("2006-01-02 15:04:05"),
+			QuestionID:    sc.GetQuestionId(),
+			QuestionTitle: titles[sc.GetQuestionId()],
+			ParticipantID: sc.GetSolverId(),
+			Participant:   names[sc.GetSolverId()],
+			OldPoints:     sc.GetOldPoints(),
+			NewPoints:     sc.GetNewPoints(),
+			ChangedBy:     sc.GetChangedByName(),
+		}
+		if row.QuestionTitle == "" {
+			row.QuestionTitle = fmt.Sprintf("Question %v", sc.GetQuestionId())
+		}
+		if row.Participant == "" {
+			row.Participant = fmt.Sprintf("Participant %v", sc.GetSolverId())
+		}
+		if sc.GetChangedBy() == 0 {
+			row.ChangedBy = "Automatic grading"
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// RenderQuestionScoreLog shows the quizmaster every change to the points of the answers to a question.
+func (c *Controller) RenderQuestionScoreLog(w http.ResponseWriter, r *http.Request) {
+	qnid, err := strconv.Atoi(mux.Vars(r)["questionid"])
+	if view.Should500(err, w, "could not parse question id") {
+		return
+	}
+	c.renderScoreLog(w, r, func(qz *model.Quiz) (string, []*model.ScoreChange, error) {
+		for _, qn := range qz.GetQuestions() {
+			if qn.GetId() == int64(qnid) {
+				changes, err := c.P.GetScoreChangesForQuestion(int64(qnid))
+				return qn.GetTitle(), changes, err
+			}
+		}
+		return "", nil, fmt.Errorf("question %v is not in quiz %v", qnid, qz.GetId())
+	})
+}
+
+// RenderParticipantScoreLog shows the quizmaster every change to the points of a participant.
+func (c *Controller) RenderParticipantScoreLog(w http.ResponseWriter, r *http.Request) {
+	ppid, err := strconv.Atoi(mux.Vars(r)["participantid"])
+	if view.Should500(err, w, "could not parse participant id") {
+		return
+	}
+	c.renderScoreLog(w, r, func(qz *model.Quiz) (string, []*model.ScoreChange, error) {
+		for _, pp := range qz.GetParticipants() {
+			if pp.GetUserId() == int64(ppid) {
+				changes, err := c.P.GetScoreChangesForParticipant(qz.GetId(), int64(ppid))
+				return pp.GetProfileName(), changes, err
+			}
+		}
+		return "", nil, fmt.Errorf("user %v is not a participant of quiz %v", ppid, qz.GetId())
+	})
+}
+
+// renderScoreLog checks that the user is a quizmaster of the quiz, and shows the score changes
+// picked out by getChanges along with the name of what they were picked for.
+func (c *Controller) renderScoreLog(w http.ResponseWriter, r *http.Request,
+	getChanges func(qz *model.Quiz) (string, []*model.ScoreChange, error)) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.RedirToLoginIfError(err, w, r) {
+		return
+	}
+	qzid, err := strconv.Atoi(mux.Vars(r)["quizid"])
+	if view.Should500(err, w, "could not parse quiz id") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(int64(qzid), u), w,
+		"You do not have access to the scores of this quiz. Please <a href='/logout'>Logout</a>"+
+			" and then log in again with an account that has access.") {
+		return
+	}
+	qz, err := c.P.GetQuiz(int64(qzid))
+	if view.Should500(err, w, "could not fetch quiz") {
+		return
+	}
+	name, changes, err := getChanges(qz)
+	if view.Should500(err, w, "could not fetch the score changes") {
+		return
+	}
+
+	type scoreLog struct {
+		QuizID   int64
+		QuizName string
+		Subject  string
+		Rows     []scoreLogRow
+		U        *model.User
+	}
+	c.V.RenderTemplate(w, "qm_scorelog.html", scoreLog{
+		QuizID:   qz.GetId(),
+		QuizName: qz.GetTitle(),
+		Subject:  name,
+		Rows:     getScoreLogRows(qz, changes, time.Local),
+		U:        u,
+	})
+}
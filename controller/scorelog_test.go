@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetScoreLogRows(t *testing.T) {
+	qz := &model.Quiz{
+		Questions:    []*model.Question{{Id: proto.Int64(4), Title: proto.String("Capitals")}},
+		Participants: []*model.ParticipantProfile{{UserId: proto.Int64(9), ProfileName: proto.String("Owls")}},
+	}
+	changes := []*model.ScoreChange{
+		{QuestionId: proto.Int64(4), SolverId: proto.Int64(9), OldPoints: proto.Int64(0), NewPoints: proto.Int64(4),
+			ChangedAtMs: proto.Int64(0)},
+		{QuestionId: proto.Int64(5), SolverId: proto.Int64(8), OldPoints: proto.Int64(4), NewPoints: proto.Int64(2),
+			ChangedBy: proto.Int64(1), ChangedByName: proto.String("Guest 1"), ChangedAtMs: proto.Int64(61000)},
+	}
+	want := []scoreLogRow{
+		{When: "1970-01-01 00:00:00", QuestionID: 4, QuestionTitle: "Capitals", ParticipantID: 9, Participant: "Owls",
+			NewPoints: 4, ChangedBy: "Automatic grading"},
+		{When: "1970-01-01 00:01:01", QuestionID: 5, QuestionTitle: "Question 5", ParticipantID: 8,
+			Participant: "Participant 8", OldPoints: 4, NewPoints: 2, ChangedBy: "Guest 1"},
+	}
+	if got := getScoreLogRows(qz, changes, time.UTC); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestScoreChangesAreLogged(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris")
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Owls", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+	ansid := callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Paris", tq.qzid, tq.qnid), tq.ppCookie, nil, c.SubmitAnswer).resptext
+
+	vars := map[string]string{"questionid": tq.qnid}
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=0", ansid), tq.ppCookie, vars, c.SaveScores)
+	if r.statuscode != 401 {
+		t.Errorf("want: HTTP 401 for scores saved by a participant. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r = callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=3", ansid), tq.qmCookie, vars, c.SaveScores)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to save scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	// Saving the same score again is not a change.
+	callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", tq.qnid),
+		fmt.Sprintf("ans-%v-score=3", ansid), tq.qmCookie, vars, c.SaveScores)
+
+	qn, _ := strconv.ParseInt(tq.qnid, 10, 64)
+	changes, err := p.GetScoreChangesForQuestion(qn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("want the grading on submission and the quizmaster's score logged, got %v", changes)
+	}
+	id, _ := strconv.ParseInt(ansid, 10, 64)
+	auto, byQM := changes[0], changes[1]
+	if auto.GetAnswerId() != id || auto.GetOldPoints() != 0 || auto.GetNewPoints() != 4 || auto.GetChangedBy() != 0 {
+		t.Errorf("want the automatic grading from 0 to 4 points first, got %v", auto)
+	}
+	if byQM.GetOldPoints() != 4 || byQM.GetNewPoints() != 3 || byQM.GetChangedBy() == 0 ||
+		byQM.GetChangedByName() != fmt.Sprintf("Guest %v", byQM.GetChangedBy()) {
+		t.Errorf("want the quizmaster's change from 4 to 3 points next, got %v", byQM)
+	}
+	byPP, err := p.GetScoreChangesForParticipant(auto.GetQuizId(), auto.GetSolverId())
+	if err != nil || len(byPP) != 2 {
+		t.Errorf("want the same changes for the participant, got %v. %v", byPP, err)
+	}
+
+	qnLog := fmt.Sprintf("/quizmaster/quiz/%v/scorelog/question/%v", tq.qzid, tq.qnid)
+	qzvars := map[string]string{"quizid": tq.qzid, "questionid": tq.qnid}
+	if r := callController("GET", qnLog, "", tq.ppCookie, qzvars, c.RenderQuestionScoreLog); r.statuscode != 401 {
+		t.Errorf("want: HTTP 401 for the score log seen by a participant. got: HTTP %v", r.statuscode)
+	}
+	if r := callController("GET", qnLog, "", tq.qmCookie, qzvars, c.RenderQuestionScoreLog); r.statuscode != 200 ||
+		!strings.Contains(r.resptext, "Automatic grading") {
+		t.Errorf("want: HTTP 200 for the score log seen by the quizmaster. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	ppvars := map[string]string{"quizid": tq.qzid, "participantid": strconv.FormatInt(auto.GetSolverId(), 10)}
+	ppLog := fmt.Sprintf("/quizmaster/quiz/%v/scorelog/participant/%v", tq.qzid, ppvars["participantid"])
+	if r := callController("GET", ppLog, "", tq.qmCookie, ppvars, c.RenderParticipantScoreLog); r.statuscode != 200 {
+		t.Errorf("want: HTTP 200 for the participant's score log. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+}
+
+func TestFirstGradeOfZeroIsLogged(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-points=4&qn-key-text=Paris")
+	r := callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Rome", tq.qzid, tq.qnid), tq.ppCookie, nil, c.SubmitAnswer)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	qn, _ := strconv.ParseInt(tq.qnid, 10, 64)
+	changes, err := p.GetScoreChangesForQuestion(qn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].OldPoints != nil || changes[0].NewPoints == nil || changes[0].GetNewPoints() != 0 {
+		t.Errorf("want the wrong answer graded from nothing to 0 points logged, got %v", changes)
+	}
+}
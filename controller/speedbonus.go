@@ -0,0 +1,100 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"quizdrum/model"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// getSpeedBonusFromPostForm reads the speed bonus of a quiz from the quiz properties form.
+// qz-speed-bonus is the formula, qz-speed-bonus-points the most points it gives, and the
+// optional qz-speed-bonus-window how many seconds it lasts. It returns nil for no bonus.
+func getSpeedBonusFromPostForm(p url.Values) (*model.SpeedBonus, error) {
+	var sb model.SpeedBonus
+	switch p.Get("qz-speed-bonus") {
+	case "", "none":
+		return nil, nil
+	case "linear":
+		sb.Formula = model.SpeedBonusFormula_LINEAR_DECAY.Enum()
+	default:
+		return nil, fmt.Errorf("unexpected speed bonus formula: %v", p.Get("qz-speed-bonus"))
+	}
+	pts, err := strconv.ParseInt(p.Get("qz-speed-bonus-points"), 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	if pts <= 0 {
+		return nil, fmt.Errorf("the speed bonus must be worth some points, got %v", pts)
+	}
+	sb.MaxPoints = proto.Int64(pts)
+	if val := p.Get("qz-speed-bonus-window"); val != "" {
+		window, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		if window < 0 {
+			return nil, fmt.Errorf("the speed bonus window cannot be negative: %v", window)
+		}
+		if window > 0 {
+			sb.WindowS = proto.Int64(window)
+		}
+	}
+	return &sb, nil
+}
+
+// setElapsedTime records on the answer how long after its question was activated it came in.
+func setElapsedTime(qz *model.Quiz, ans *model.Answer, now time.Time) {
+	at, ok := qz.GetQuestionActivatedMs()[ans.GetQuestionId()]
+	if !ok {
+		return
+	}
+	ans.ElapsedMs = proto.Int64(max(0, now.UnixMilli()-at))
+}
+
+// getSpeedBonus returns the points the answer gets on top of the points for the question for
+// being quick. Only answers that got all the points for the question get a bonus, so that the
+// bonus follows the quizmaster if they change the points by hand.
+func getSpeedBonus(qz *model.Quiz, qn *model.Question, ans *model.Answer) int64 {
+	sb := qz.GetSpeedBonus()
+	full := getQuestionPoints(qn)
+	if sb.GetFormula() == model.SpeedBonusFormula_NO_SPEED_BONUS || ans.ElapsedMs == nil ||
+		full <= 0 || ans.GetPointsAwarded() < full {
+		return 0
+	}
+	window := sb.GetWindowS()
+	if window <= 0 {
+		window = qn.GetTimeLimitS()
+	}
+	if window <= 0 {
+		return 0
+	}
+	windowMs := window * 1000
+	switch sb.GetFormula() {
+	case model.SpeedBonusFormula_LINEAR_DECAY:
+		left := windowMs - ans.GetElapsedMs()
+		if left <= 0 {
+			return 0
+		}
+		return int64(math.Round(float64(sb.GetMaxPoints()*left) / float64(windowMs)))
+	}
+	return 0
+}
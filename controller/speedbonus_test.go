@@ -0,0 +1,136 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"strconv"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetSpeedBonus(t *testing.T) {
+	linear := func(window int64) *model.Quiz {
+		sb := &model.SpeedBonus{Formula: model.SpeedBonusFormula_LINEAR_DECAY.Enum(), MaxPoints: proto.Int64(6)}
+		if window > 0 {
+			sb.WindowS = proto.Int64(window)
+		}
+		return &model.Quiz{SpeedBonus: sb}
+	}
+	timed := &model.Question{TimeLimitS: proto.Int64(30)}
+	untimed := &model.Question{}
+	answer := func(points int64, elapsedMs int64) *model.Answer {
+		return &model.Answer{PointsAwarded: proto.Int64(points), ElapsedMs: proto.Int64(elapsedMs)}
+	}
+	tests := []struct {
+		qz   *model.Quiz
+		qn   *model.Question
+		ans  *model.Answer
+		want int64
+	}{
+		{linear(0), timed, answer(10, 0), 6},
+		{linear(0), timed, answer(10, 15000), 3},
+		{linear(0), timed, answer(10, 29000), 0},
+		{linear(0), timed, answer(10, 45000), 0},
+		{linear(60), timed, answer(10, 15000), 5},
+		{linear(60), untimed, answer(10, 30000), 3},
+		// No time limit and no window means there is nothing to measure against.
+		{linear(0), untimed, answer(10, 1000), 0},
+		// Only answers with all the points get a bonus.
+		{linear(0), timed, answer(5, 0), 0},
+		{linear(0), timed, &model.Answer{PointsAwarded: proto.Int64(10)}, 0},
+		{&model.Quiz{}, timed, answer(10, 0), 0},
+	}
+	for _, tc := range tests {
+		if got := getSpeedBonus(tc.qz, tc.qn, tc.ans); got != tc.want {
+			t.Errorf("getSpeedBonus(%v, %v, %v) = %v, want %v", tc.qz, tc.qn, tc.ans, got, tc.want)
+		}
+	}
+}
+
+func TestGetSpeedBonusFromPostForm(t *testing.T) {
+	sb, err := getSpeedBonusFromPostForm(url.Values{"qz-speed-bonus": {"none"}, "qz-speed-bonus-points": {"5"}})
+	if err != nil || sb != nil {
+		t.Errorf("want no speed bonus, got %v, %v", sb, err)
+	}
+	sb, err = getSpeedBonusFromPostForm(url.Values{
+		"qz-speed-bonus": {"linear"}, "qz-speed-bonus-points": {"5"}, "qz-speed-bonus-window": {"20"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sb.GetFormula() != model.SpeedBonusFormula_LINEAR_DECAY || sb.GetMaxPoints() != 5 || sb.GetWindowS() != 20 {
+		t.Errorf("want a linear bonus of 5 points over 20s, got %v", sb)
+	}
+	for _, v := range []url.Values{
+		{"qz-speed-bonus": {"linear"}},
+		{"qz-speed-bonus": {"linear"}, "qz-speed-bonus-points": {"0"}},
+		{"qz-speed-bonus": {"linear"}, "qz-speed-bonus-points": {"5"}, "qz-speed-bonus-window": {"-1"}},
+		{"qz-speed-bonus": {"fastest"}, "qz-speed-bonus-points": {"5"}},
+	} {
+		if _, err := getSpeedBonusFromPostForm(v); err == nil {
+			t.Errorf("want an error for %v", v)
+		}
+	}
+}
+
+func TestElapsedTimeIsRecorded(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	vars := map[string]string{"quizid": tq.qzid}
+	r := callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/updateproperties", tq.qzid),
+		"qz-title=Fast&qz-descr=Fast&qz-speed-bonus=linear&qz-speed-bonus-points=5", tq.qmCookie, vars, c.UpdateQuizProperties)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to set the speed bonus. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	qnid := tq.addQuestion(t, c, "qn-title=Q&qn-body=Capital&qn-type=text&qn-time-limit=60&qn-key-text=Paris")
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Quick", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+	tq.setActive(t, c, qnid)
+
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuiz(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qn, _ := strconv.ParseInt(qnid, 10, 64)
+	if _, ok := qz.GetQuestionActivatedMs()[qn]; !ok || qz.GetSpeedBonus().GetMaxPoints() != 5 {
+		t.Fatalf("want the activation time and the speed bonus on the quiz, got %v", qz)
+	}
+
+	r = callController("POST", "/api/participant/submit-answer",
+		fmt.Sprintf("qz-id=%v&qn-id=%v&ans-text=Paris", tq.qzid, qnid), tq.ppCookie, nil, c.SubmitAnswer)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	answers, err := p.GetAllAnswersToQuestionID(uint(qn))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(answers) != 1 || answers[0].ElapsedMs == nil || answers[0].GetElapsedMs() > 60000 {
+		t.Fatalf("want the elapsed time on the answer, got %v", answers)
+	}
+	// Answered right away, so the bonus rounds to all of it.
+	ansmap, err := p.GetAllAnswersForSetOfQuestions(qz.GetQuestions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total := getScoreboard(qz, ansmap).PAndScore[0].Total; total != 15 {
+		t.Errorf("want 10 points and a speed bonus of 5, got %v", total)
+	}
+}
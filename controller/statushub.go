@@ -0,0 +1,81 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"sync"
+)
+
+// quizStatus is what participants need to know to follow a live quiz.
+// It is sent both by the polling API and the server-sent event stream.
+type quizStatus struct {
+	QuestionID         int64
+	AcceptingResponses bool
+	// RemainingMs is how long is left to respond to the live question.
+	// It is only set while a countdown is running.
+	RemainingMs int64 `json:",omitempty"`
+	// Revealed is set while the solution to the live question is shown.
+	Revealed bool `json:",omitempty"`
+	// Lobby is only set while participants are waiting for a scheduled quiz to start.
+	Lobby *lobbyStatus `json:",omitempty"`
+	// RoundID is set while the title card of the round is shown.
+	RoundID int64 `json:",omitempty"`
+}
+
+// statusHub fans out quiz status changes to the participants listening on a quiz.
+// The zero value is ready to use.
+type statusHub struct {
+	mu   sync.Mutex
+	subs map[int64]map[chan quizStatus]struct{}
+}
+
+// subscribe registers a listener for status changes to the given quiz.
+// The returned function must be called to release the listener.
+func (h *statusHub) subscribe(qzid int64) (<-chan quizStatus, func()) {
+	// The channel holds only the latest status, so a slow reader skips
+	// intermediate states instead of blocking the publisher.
+	ch := make(chan quizStatus, 1)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.subs == nil {
+		h.subs = make(map[int64]map[chan quizStatus]struct{})
+	}
+	if h.subs[qzid] == nil {
+		h.subs[qzid] = make(map[chan quizStatus]struct{})
+	}
+	h.subs[qzid][ch] = struct{}{}
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.subs[qzid], ch)
+		if len(h.subs[qzid]) == 0 {
+			delete(h.subs, qzid)
+		}
+	}
+}
+
+// publish sends the status to every listener on the given quiz without blocking.
+func (h *statusHub) publish(qzid int64, st quizStatus) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[qzid] {
+		// Drop the pending status, if any, since it is now stale.
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- st
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bufio"
+	"fmt"
+	"net/http/httptest"
+	"quizdrum/model"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStatusHubKeepsOnlyLatestStatus(t *testing.T) {
+	var h statusHub
+	ch, unsubscribe := h.subscribe(7)
+	other, unsubscribeOther := h.subscribe(8)
+	defer unsubscribeOther()
+
+	h.publish(7, quizStatus{QuestionID: 1, AcceptingResponses: true})
+	h.publish(7, quizStatus{QuestionID: 2, AcceptingResponses: false})
+
+	if got, want := <-ch, (quizStatus{QuestionID: 2}); got != want {
+		t.Errorf("got status %+v, want %+v", got, want)
+	}
+	select {
+	case st := <-other:
+		t.Errorf("listener on another quiz got status %+v", st)
+	default:
+	}
+
+	unsubscribe()
+	if _, ok := h.subs[7]; ok {
+		t.Errorf("quiz still has listeners after unsubscribing")
+	}
+	// Publishing to a quiz nobody listens to must not block.
+	h.publish(7, quizStatus{QuestionID: 3})
+}
+
+func TestStreamQuizStatus(t *testing.T) {
+	c, p := newTestController(t)
+	qzid, err := p.CreateQuiz(&model.Quiz{
+		Title:       proto.String("Streamed"),
+		Quizmasters: []*model.QuizmasterProfile{{UserId: proto.Int64(1)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := mux.NewRouter()
+	r.HandleFunc("/api/participant/quiz/{quizid}/statusstream", c.StreamQuizStatus)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	resp, err := srv.Client().Get(fmt.Sprintf("%v/api/participant/quiz/%v/statusstream", srv.URL, qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("wrong content type. want text/event-stream, got %v", ct)
+	}
+
+	events := make(chan string, 10)
+	go func() {
+		sc := bufio.NewScanner(resp.Body)
+		for sc.Scan() {
+			if d := strings.TrimPrefix(sc.Text(), "data: "); d != sc.Text() {
+				events <- d
+			}
+		}
+		close(events)
+	}()
+	next := func() string {
+		select {
+		case e := <-events:
+			return e
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a status event")
+		}
+		return ""
+	}
+
+	if got, want := next(), `{"QuestionID":0,"AcceptingResponses":false}`; got != want {
+		t.Errorf("wrong initial status. want %v, got %v", want, got)
+	}
+	qz, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	qz.LiveQuestionId = proto.Int64(4)
+	qz.AcceptingResponses = proto.Bool(true)
+	if err := p.SaveQuiz(qz); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := next(), `{"QuestionID":4,"AcceptingResponses":true}`; got != want {
+		t.Errorf("wrong pushed status. want %v, got %v", want, got)
+	}
+}
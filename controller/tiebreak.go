@@ -0,0 +1,92 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"quizdrum/model"
+)
+
+// tieBreakAnswer is how close a participant came on a tie-breaker question.
+type tieBreakAnswer struct {
+	Answered bool
+	// Distance is how far the answer was from the answer key.
+	Distance float64
+	// ResponseTimeS and ElapsedMs tell when the answer came in, so that the earlier of
+	// two equally close answers wins.
+	ResponseTimeS int64
+	ElapsedMs     int64
+}
+
+// checkTieBreaker returns an error if the question cannot be a tie-breaker, which needs a
+// number to be close to.
+func checkTieBreaker(qn *model.Question) error {
+	if qn.GetType() != model.AnswerType_INT64_ANSWER && qn.GetType() != model.AnswerType_FLOAT_ANSWER {
+		return errors.New("a tie-breaker has to be a number question")
+	}
+	if _, _, ok := getCorrectRange(qn); !ok {
+		return errors.New("a tie-breaker needs the correct answer in the answer key")
+	}
+	if qn.GetWager() {
+		return errors.New("a tie-breaker cannot be a wager question")
+	}
+	return nil
+}
+
+// getTieBreakAnswer works out how close the answer to a tie-breaker question came.
+func getTieBreakAnswer(qn *model.Question, ans *model.Answer) tieBreakAnswer {
+	v, ok := getNumericAnswer(ans)
+	lo, hi, keyed := getCorrectRange(qn)
+	if !ok || !keyed || ans.GetType() != qn.GetType() {
+		return tieBreakAnswer{}
+	}
+	var distance float64
+	if v < lo {
+		distance = lo - v
+	} else if v > hi {
+		distance = v - hi
+	}
+	return tieBreakAnswer{
+		Answered:      true,
+		Distance:      math.Abs(distance),
+		ResponseTimeS: ans.GetResponseTimeS(),
+		ElapsedMs:     ans.GetElapsedMs(),
+	}
+}
+
+// compareTieBreaks returns a negative number if a ranks above b on the tie-breaker questions,
+// which are compared in the order of the quiz. An answer ranks above no answer, a closer answer
+// above one further away, and an earlier answer above a later one.
+func compareTieBreaks(a, b []tieBreakAnswer) int {
+	for i := range min(len(a), len(b)) {
+		x, y := a[i], b[i]
+		if x.Answered != y.Answered {
+			if x.Answered {
+				return -1
+			}
+			return 1
+		}
+		if !x.Answered {
+			continue
+		}
+		if c := cmp.Or(cmp.Compare(x.Distance, y.Distance), cmp.Compare(x.ResponseTimeS, y.ResponseTimeS),
+			cmp.Compare(x.ElapsedMs, y.ElapsedMs)); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
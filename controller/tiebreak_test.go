@@ -0,0 +1,93 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"net/url"
+	"quizdrum/model"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestTieBreakerFromPostBody(t *testing.T) {
+	tests := []struct {
+		v  url.Values
+		ok bool
+	}{
+		{url.Values{"qn-type": {"int"}, "qn-key-int": {"100"}}, true},
+		{url.Values{"qn-type": {"float"}, "qn-key-min": {"1.5"}, "qn-key-max": {"2.5"}}, true},
+		{url.Values{"qn-type": {"int"}}, false},
+		{url.Values{"qn-type": {"text"}, "qn-key-text": {"Paris"}}, false},
+		{url.Values{"qn-type": {"int"}, "qn-key-int": {"100"}, "qn-wager": {"true"}}, false},
+	}
+	for _, tc := range tests {
+		tc.v.Set("quiz-id", "1")
+		tc.v.Set("qn-title", "Q")
+		tc.v.Set("qn-body", "How many?")
+		tc.v.Set("qn-tie-breaker", "true")
+		qn, err := GetQuestionFromPostBody(tc.v)
+		if (err == nil) != tc.ok {
+			t.Errorf("%v: want ok %v, got %v", tc.v, tc.ok, err)
+			continue
+		}
+		if err == nil && !qn.GetTieBreaker() {
+			t.Errorf("%v: want a tie-breaker", tc.v)
+		}
+	}
+}
+
+func TestScoreboardTieBreak(t *testing.T) {
+	q1 := &model.Question{Id: proto.Int64(1), Title: proto.String("One")}
+	tb := &model.Question{Id: proto.Int64(2), Title: proto.String("Closest"), TieBreaker: proto.Bool(true),
+		Type: model.AnswerType_INT64_ANSWER.Enum(), AnswerKey: &model.AnswerKey{CorrectInt: proto.Int64(100)}}
+	qz := &model.Quiz{Questions: []*model.Question{q1, tb}}
+	for i, name := range []string{"Ann", "Bob", "Cat", "Dan", "Eve"} {
+		qz.Participants = append(qz.Participants,
+			&model.ParticipantProfile{UserId: proto.Int64(int64(i + 1)), ProfileName: proto.String(name)})
+	}
+	tbAns := func(solver, v, at int64) *model.Answer {
+		return &model.Answer{SolverId: proto.Int64(solver), Type: model.AnswerType_INT64_ANSWER.Enum(),
+			AnsInt: proto.Int64(v), ResponseTimeS: proto.Int64(at), PointsAwarded: proto.Int64(50)}
+	}
+	ansmap := map[*model.Question][]*model.Answer{
+		q1: {
+			{SolverId: proto.Int64(1), PointsAwarded: proto.Int64(10)},
+			{SolverId: proto.Int64(2), PointsAwarded: proto.Int64(10)},
+			{SolverId: proto.Int64(3), PointsAwarded: proto.Int64(10)},
+			{SolverId: proto.Int64(4), PointsAwarded: proto.Int64(5)},
+			{SolverId: proto.Int64(5), PointsAwarded: proto.Int64(10)},
+		},
+		tb: {tbAns(1, 90, 20), tbAns(2, 110, 10), tbAns(4, 100, 5), tbAns(5, 150, 1)},
+	}
+
+	board := getScoreboard(qz, ansmap)
+	if !reflect.DeepEqual(board.QuestionTitle, []string{"One"}) || board.PAndScore[0].Total != 10 {
+		t.Errorf("want the tie-breaker left out of the scores, got %+v", board)
+	}
+	board.sortByTotal()
+	var got []string
+	for _, row := range board.PAndScore {
+		got = append(got, row.ParticipantName)
+	}
+	if want := []string{"Bob", "Ann", "Eve", "Cat", "Dan"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got ranking %v, want %v", got, want)
+	}
+
+	if _, ok := gradeAnswer(qz, tb, tbAns(1, 100, 1)); ok {
+		t.Errorf("want tie-breakers left out of automatic grading")
+	}
+}
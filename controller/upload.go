@@ -0,0 +1,173 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"quizdrum/model"
+	"quizdrum/view"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// maxUploadBytes is the largest file a participant can upload as an answer.
+	maxUploadBytes = 5 << 20
+	// maxUploadFormBytes is how much more than the file the rest of the form can take up.
+	maxUploadFormBytes = 64 << 10
+)
+
+var (
+	// allowedUploadTypes are the kinds of file that can be uploaded, as worked out from
+	// the file itself rather than what the browser says.
+	allowedUploadTypes = map[string]bool{
+		"image/png":  true,
+		"image/jpeg": true,
+		"image/gif":  true,
+		"image/webp": true,
+	}
+
+	errUploadTooLarge = fmt.Errorf("the file is larger than %v MB", maxUploadBytes>>20)
+	errUploadType     = errors.New("only PNG, JPEG, GIF and WebP images can be uploaded")
+	errUploadMissing  = errors.New("choose a file to upload")
+)
+
+// parseAnswerForm parses the form of a submitted answer, which is a multipart form if it
+// has a file. It writes the error and returns false if the form could not be read.
+func parseAnswerForm(w http.ResponseWriter, r *http.Request) bool {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+		r.ParseForm()
+		return true
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxUploadBytes+maxUploadFormBytes)
+	err := r.ParseMultipartForm(maxUploadBytes)
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		fmt.Fprintf(w, "%v", errUploadTooLarge)
+		return false
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, "could not read the form: %v", err)
+		return false
+	}
+	return true
+}
+
+// storeUploadFromForm stores the ans-upload file of the form, if the question takes a file, and
+// puts its ID in the ans-upload field so that GetAnswerFromPostBody finds it. The question must
+// be live. Without a new file, the file of the earlier answer of the participant is kept. It
+// returns the ID of the new file, which must be deleted unless the answer is saved, and the ID
+// of the earlier file if the new one replaces it, which can be deleted once the answer is saved.
+func (c *Controller) storeUploadFromForm(r *http.Request, qz *model.Quiz, qn *model.Question,
+	u *model.User) (stored uint, replaced uint, err error) {
+	// Only a file stored here can be the answer, never an ID sent in by the participant.
+	r.PostForm.Del("ans-upload")
+	if qn.GetType() != model.AnswerType_UPLOAD_ANSWER {
+		return 0, 0, nil
+	}
+	var earlier uint
+	// Ignoring errors here, since it could just be the case that the answer does not exist
+	if ans, err := c.P.GetAnswerByUserAndQuestion(u, qn); err == nil {
+		earlier = uint(ans.GetUploadId())
+	}
+	var f multipart.File
+	err = http.ErrMissingFile
+	if r.MultipartForm != nil {
+		f, _, err = r.FormFile("ans-upload")
+	}
+	if err == http.ErrMissingFile {
+		if earlier == 0 {
+			return 0, 0, errUploadMissing
+		}
+		r.PostForm.Set("ans-upload", strconv.FormatUint(uint64(earlier), 10))
+		return 0, 0, nil
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxUploadBytes+1))
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(data) > maxUploadBytes {
+		return 0, 0, errUploadTooLarge
+	}
+	mimeType := http.DetectContentType(data)
+	if !allowedUploadTypes[mimeType] {
+		return 0, 0, errUploadType
+	}
+	id, err := c.P.CreateUpload(&model.Upload{
+		QuizId:     proto.Int64(qz.GetId()),
+		UploaderId: proto.Int64(u.GetId()),
+		MimeType:   proto.String(mimeType),
+		Data:       data,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+	r.PostForm.Set("ans-upload", strconv.FormatUint(uint64(id), 10))
+	return id, earlier, nil
+}
+
+// deleteUpload deletes a file that no answer points at. The request has done its work
+// by now, so a failure is only logged.
+func (c *Controller) deleteUpload(id uint) {
+	if err := c.P.DeleteUpload(id); err != nil {
+		log.Printf("could not delete the upload %v: %v", id, err)
+	}
+}
+
+// getUploadURL is where the quizmasters can see the file uploaded as the answer.
+func getUploadURL(ans *model.Answer) string {
+	if ans.GetType() != model.AnswerType_UPLOAD_ANSWER {
+		return ""
+	}
+	return fmt.Sprintf("/api/quizmaster/upload/%v", ans.GetUploadId())
+}
+
+// GetUpload sends an uploaded file to a quizmaster of the quiz it was uploaded for.
+func (c *Controller) GetUpload(w http.ResponseWriter, r *http.Request) {
+	u, err := c.P.GetUserFromCookieAndError(r.Cookie("sid"))
+	if view.UnauthIfError(err, w, "cookie error, please logout and then login again") {
+		return
+	}
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["uploadid"])
+	if view.Should500(err, w, "could not parse upload id") {
+		return
+	}
+	up, err := c.P.GetUploadByID(uint(id))
+	if view.Should500(err, w, "could not find the upload") {
+		return
+	}
+	if view.UnauthIfError(c.P.ValidateWritePrivileges(up.GetQuizId(), u), w, "no write privileges") {
+		return
+	}
+	w.Header().Set("Content-Type", up.GetMimeType())
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=3600")
+	w.Write(up.GetData())
+}
@@ -0,0 +1,151 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"quizdrum/model"
+	"strconv"
+	"testing"
+)
+
+// submitUpload submits the given file as the answer, along with the other form values.
+func submitUpload(c *Controller, cookie *http.Cookie, values map[string]string, file []byte) savedHTTPResponse {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range values {
+		mw.WriteField(k, v)
+	}
+	if file != nil {
+		fw, _ := mw.CreateFormFile("ans-upload", "answer.png")
+		fw.Write(file)
+	}
+	mw.Close()
+	req := httptest.NewRequest("POST", "/api/participant/submit-answer", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.AddCookie(cookie)
+	resp := httptest.NewRecorder()
+	c.SubmitAnswer(resp, req)
+	return savedHTTPResponse{statuscode: resp.Code, resptext: resp.Body.String()}
+}
+
+func TestUploadAnswer(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Draw+a+cat&qn-type=upload")
+
+	form := map[string]string{"qz-id": tq.qzid, "qn-id": tq.qnid, "ans-upload": "1000"}
+	if r := submitUpload(c, tq.ppCookie, form, []byte("just some text")); r.statuscode != http.StatusUnsupportedMediaType {
+		t.Errorf("want: HTTP 415 for a text file. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submitUpload(c, tq.ppCookie, form, make([]byte, maxUploadBytes+1)); r.statuscode != http.StatusRequestEntityTooLarge {
+		t.Errorf("want: HTTP 413 for a large file. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	png := []byte("\x89PNG\r\n\x1a\nrest of the image")
+	if r := submitUpload(c, tq.ppCookie, form, png); r.statuscode != http.StatusOK {
+		t.Fatalf("want: HTTP 200 for a PNG. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	id, _ := strconv.Atoi(tq.qnid)
+	answers, err := p.GetAllAnswersToQuestionID(uint(id))
+	if err != nil || len(answers) != 1 {
+		t.Fatalf("want one answer, got %v. %v", answers, err)
+	}
+	ans := answers[0]
+	if ans.GetType() != model.AnswerType_UPLOAD_ANSWER || ans.GetUploadId() == 1000 {
+		t.Fatalf("want the stored upload as the answer, not the one sent in the form. got %v", ans)
+	}
+
+	upid := strconv.FormatInt(ans.GetUploadId(), 10)
+	vars := map[string]string{"uploadid": upid}
+	if r := callController("GET", "/api/quizmaster/upload/"+upid, "", tq.ppCookie, vars, c.GetUpload); r.statuscode != http.StatusUnauthorized {
+		t.Errorf("want: HTTP 401 for a participant. got: HTTP %v", r.statuscode)
+	}
+	r := callController("GET", "/api/quizmaster/upload/"+upid, "", tq.qmCookie, vars, c.GetUpload)
+	if r.statuscode != http.StatusOK || r.resptext != string(png) {
+		t.Errorf("want: HTTP 200 and the file for the quizmaster. got: HTTP %v. %q", r.statuscode, r.resptext)
+	}
+}
+
+func TestReplaceUpload(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Draw+a+cat&qn-type=upload")
+	form := map[string]string{"qz-id": tq.qzid, "qn-id": tq.qnid}
+	if r := submitUpload(c, tq.ppCookie, form, nil); r.statuscode != http.StatusBadRequest {
+		t.Errorf("want: HTTP 400 for a first answer without a file. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	r := submitUpload(c, tq.ppCookie, form, []byte("\x89PNG\r\n\x1a\nfirst cat"))
+	if r.statuscode != http.StatusOK {
+		t.Fatalf("want: HTTP 200 for a PNG. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	form["ans-id"] = r.resptext
+
+	id, _ := strconv.Atoi(tq.qnid)
+	uploadID := func() int64 {
+		answers, err := p.GetAllAnswersToQuestionID(uint(id))
+		if err != nil || len(answers) != 1 {
+			t.Fatalf("want one answer, got %v. %v", answers, err)
+		}
+		return answers[0].GetUploadId()
+	}
+	first := uploadID()
+	if r := submitUpload(c, tq.ppCookie, form, nil); r.statuscode != http.StatusOK {
+		t.Fatalf("want: HTTP 200 for a resubmission without a file. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if got := uploadID(); got != first {
+		t.Errorf("want the file %v kept without a new one, got %v", first, got)
+	}
+
+	if r := submitUpload(c, tq.ppCookie, form, []byte("\x89PNG\r\n\x1a\nsecond cat")); r.statuscode != http.StatusOK {
+		t.Fatalf("want: HTTP 200 for a new PNG. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if got := uploadID(); got == first {
+		t.Errorf("want the new file as the answer, got %v", got)
+	}
+	if _, err := p.GetUploadByID(uint(first)); err == nil {
+		t.Errorf("want the replaced file %v deleted", first)
+	}
+}
+
+func TestUploadToTextQuestion(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Capital&qn-type=text")
+	form := map[string]string{"qz-id": tq.qzid, "qn-id": tq.qnid, "ans-text": "Paris"}
+	if r := submitUpload(c, tq.ppCookie, form, []byte("\x89PNG\r\n\x1a\nnot a capital")); r.statuscode != http.StatusOK {
+		t.Fatalf("want: HTTP 200 for the text answer. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if _, err := p.GetUploadByID(1); err == nil {
+		t.Errorf("want no file stored for a text question")
+	}
+}
+
+func TestRejectedUploadIsNotKept(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q&qn-body=Draw+a+cat&qn-type=upload&qn-wager=true")
+	// There are no points to wager yet.
+	form := map[string]string{"qz-id": tq.qzid, "qn-id": tq.qnid, "ans-wager": "5"}
+	if r := submitUpload(c, tq.ppCookie, form, []byte("\x89PNG\r\n\x1a\na cat")); r.statuscode != http.StatusBadRequest {
+		t.Fatalf("want: HTTP 400 for a wager above the total. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if _, err := p.GetUploadByID(1); err == nil {
+		t.Errorf("want the file of the rejected answer deleted")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"net/url"
+	"quizdrum/model"
+	"strconv"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// getWagerFromPostForm reads ans-wager, the points bet on the answer to a wager question.
+// No wager is the same as betting nothing.
+func getWagerFromPostForm(p url.Values) (int64, error) {
+	val := p.Get("ans-wager")
+	if val == "" {
+		return 0, nil
+	}
+	w, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse the wager: %v", err)
+	}
+	if w < 0 {
+		return 0, fmt.Errorf("the wager cannot be negative: %v", w)
+	}
+	return w, nil
+}
+
+// getMaxWager is the most the participant can bet on the question, which is their total on the
+// scoreboard from all the other questions.
+func (c *Controller) getMaxWager(qzid int64, qnid int64, userID int64) (int64, error) {
+	qz, err := c.P.GetQuiz(qzid)
+	if err != nil {
+		return 0, err
+	}
+	ansmap, err := c.P.GetAllAnswersForSetOfQuestions(qz.GetQuestions())
+	if err != nil {
+		return 0, err
+	}
+	// A wager cannot be placed with the points it might win.
+	for qn := range ansmap {
+		if qn.GetId() == qnid {
+			delete(ansmap, qn)
+		}
+	}
+	for _, row := range getScoreboard(qz, ansmap).PAndScore {
+		if row.ParticipantID == userID {
+			return max(row.Total, 0), nil
+		}
+	}
+	return 0, nil
+}
+
+// setWagerFromPostForm stores the wager sent in with an answer to a wager question on the
+// answer, as long as it is no more than most.
+func setWagerFromPostForm(p url.Values, ans *model.Answer, most int64) error {
+	w, err := getWagerFromPostForm(p)
+	if err != nil {
+		return err
+	}
+	if w > most {
+		return fmt.Errorf("the wager can be at most your total of %v points, got %v", most, w)
+	}
+	ans.Wager = proto.Int64(w)
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"fmt"
+	"quizdrum/model"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestGetAnswerScore(t *testing.T) {
+	qz := &model.Quiz{}
+	wager := &model.Question{Wager: proto.Bool(true)}
+	tests := []struct {
+		qn   *model.Question
+		ans  *model.Answer
+		want int64
+	}{
+		{wager, &model.Answer{PointsAwarded: proto.Int64(10), Wager: proto.Int64(30)}, 30},
+		{wager, &model.Answer{PointsAwarded: proto.Int64(0), Wager: proto.Int64(30)}, -30},
+		{wager, &model.Answer{Wager: proto.Int64(30)}, 0},
+		{wager, nil, 0},
+		{&model.Question{}, &model.Answer{PointsAwarded: proto.Int64(10), Wager: proto.Int64(30)}, 10},
+	}
+	for _, tc := range tests {
+		if got := getAnswerScore(qz, tc.qn, tc.ans); got != tc.want {
+			t.Errorf("%v: got %v, want %v", tc.ans, got, tc.want)
+		}
+	}
+}
+
+func TestJokerStatus(t *testing.T) {
+	qz := &model.Quiz{
+		JokerEnabled: proto.Bool(true),
+		Questions: []*model.Question{
+			{Id: proto.Int64(4), RoundId: proto.Int64(1)},
+			{Id: proto.Int64(5), RoundId: proto.Int64(2)},
+			{Id: proto.Int64(6), RoundId: proto.Int64(2)},
+			{Id: proto.Int64(7)},
+		},
+		Rounds: []*model.Round{
+			{Id: proto.Int64(1), Title: proto.String("Music")},
+			{Id: proto.Int64(2), Title: proto.String("Sport")},
+			{Id: proto.Int64(3), Title: proto.String("Art")},
+		},
+		Participants: []*model.ParticipantProfile{
+			{UserId: proto.Int64(1)},
+			{UserId: proto.Int64(2), JokerRoundId: proto.Int64(2)},
+		},
+		QuestionActivatedMs: map[int64]int64{4: 1000},
+	}
+	want := &jokerStatus{Options: []jokerOption{{RoundID: 2, Title: "Sport"}, {RoundID: 3, Title: "Art"}}}
+	if got := getJokerStatus(qz, 1); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got := getJokerStatus(qz, 2); got.RoundID != 2 || got.PlayedOn != "Sport" {
+		t.Errorf("want the joker played on the round Sport, got %+v", got)
+	}
+	for rdid, ok := range map[int64]bool{1: false, 2: true, 3: true, 4: false} {
+		if err := checkJoker(qz, rdid); (err == nil) != ok {
+			t.Errorf("round %v: want ok %v, got %v", rdid, ok, err)
+		}
+	}
+
+	// The question outside of any round is not doubled.
+	answers := []*model.Answer{
+		{SolverId: proto.Int64(1), PointsAwarded: proto.Int64(10)},
+		{SolverId: proto.Int64(2), PointsAwarded: proto.Int64(10)},
+	}
+	board := getScoreboard(qz, map[*model.Question][]*model.Answer{
+		qz.GetQuestions()[1]: answers, qz.GetQuestions()[2]: answers, qz.GetQuestions()[3]: answers,
+	})
+	if board.PAndScore[0].Total != 30 || board.PAndScore[1].Total != 50 ||
+		!reflect.DeepEqual(board.PAndScore[1].RoundTotal, []int64{0, 40}) {
+		t.Errorf("want the joker to double the points in the round, got %+v", board.PAndScore)
+	}
+
+	qz.JokerEnabled = nil
+	if getJokerStatus(qz, 1) != nil || checkJoker(qz, 2) == nil {
+		t.Errorf("want no joker when the quiz does not have them")
+	}
+}
+
+func TestWagerAndJoker(t *testing.T) {
+	t.Chdir("..")
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "")
+	r := callController("PUT", fmt.Sprintf("/api/quizmaster/quiz/%v/updateproperties", tq.qzid),
+		"qz-title=Finals&qz-descr=Finals&qz-joker=true", tq.qmCookie, map[string]string{"quizid": tq.qzid}, c.UpdateQuizProperties)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to enable jokers. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	newRound := func(title string) string {
+		r := callController("POST", fmt.Sprintf("/api/quizmaster/quiz/%v/round/new", tq.qzid), "rd-title="+title,
+			tq.qmCookie, map[string]string{"quizid": tq.qzid}, c.NewRound)
+		if r.statuscode != 200 {
+			t.Fatalf("Failed to add a round. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		return strings.Trim(r.resptext, "\"\n")
+	}
+	heats, finals := newRound("Heats"), newRound("Finals")
+	first := tq.addQuestion(t, c, "qn-title=Q1&qn-body=Capital&qn-type=text&qn-key-text=Paris&qn-round="+heats)
+	final := tq.addQuestion(t, c,
+		"qn-title=Q2&qn-body=Double+or+nothing&qn-type=text&qn-key-text=Rome&qn-wager=true&qn-round="+finals)
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Owls", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+
+	joker := func(rdid string) savedHTTPResponse {
+		return callController("POST", "/api/participant/play-joker",
+			fmt.Sprintf("quiz-id=%v&rd-id=%v", tq.qzid, rdid), tq.ppCookie, nil, c.PlayJoker)
+	}
+	tq.setActive(t, c, first)
+	if r := joker(heats); r.statuscode != 400 {
+		t.Errorf("want: HTTP 400 for a joker on a round that has started. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := joker(finals); r.statuscode != 200 {
+		t.Fatalf("Failed to play the joker. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := joker(finals); r.statuscode != 400 {
+		t.Errorf("want: HTTP 400 for a second joker. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	submit := func(qnid, form string) savedHTTPResponse {
+		tq.setActive(t, c, qnid)
+		return callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&%v", tq.qzid, qnid, form), tq.ppCookie, nil, c.SubmitAnswer)
+	}
+	if r := submit(first, "ans-text=Paris"); r.statuscode != 200 {
+		t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submit(final, "ans-text=Rome&ans-wager=11"); r.statuscode != 400 {
+		t.Errorf("want: HTTP 400 for a wager above the total. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if r := submit(final, "ans-text=Rome&ans-wager=8"); r.statuscode != 200 {
+		t.Fatalf("Failed to submit the wager. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+
+	id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+	qz, err := p.GetQuiz(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ansmap, err := p.GetAllAnswersForSetOfQuestions(qz.GetQuestions())
+	if err != nil {
+		t.Fatal(err)
+	}
+	board := getScoreboard(qz, ansmap)
+	if want := []int64{10, 16}; len(board.PAndScore) != 1 || !reflect.DeepEqual(board.PAndScore[0].Score, want) ||
+		!reflect.DeepEqual(board.PAndScore[0].RoundTotal, want) {
+		t.Errorf("want the wager won and doubled by the joker, %v. got %+v", want, board.PAndScore)
+	}
+
+	r = callController("GET", fmt.Sprintf("/participant/quiz/%v/live", tq.qzid), "", tq.ppCookie,
+		map[string]string{"quizid": tq.qzid}, c.RenderLiveQuiz)
+	if r.statuscode != 200 || !strings.Contains(r.resptext, "You played your joker on the round Finals") {
+		t.Errorf("want: HTTP 200 and the joker on the live page. got: HTTP %v. %v", r.statuscode, r.resptext)
+	}
+}
+
+func TestWagerMarkedByHand(t *testing.T) {
+	c, p := newTestController(t)
+
+	tq := newTestQuiz(t, c, "qn-title=Q1&qn-body=Capital&qn-type=text&qn-key-text=Paris")
+	callController("POST", "/api/participant/set-profile",
+		fmt.Sprintf("quiz-id=%v&profile-name=Owls", tq.qzid), tq.ppCookie, nil, c.SetProfile)
+	submit := func(qnid, form string) string {
+		t.Helper()
+		r := callController("POST", "/api/participant/submit-answer",
+			fmt.Sprintf("qz-id=%v&qn-id=%v&%v", tq.qzid, qnid, form), tq.ppCookie, nil, c.SubmitAnswer)
+		if r.statuscode != 200 {
+			t.Fatalf("Failed to submit ans. HTTP %v. %v", r.statuscode, r.resptext)
+		}
+		return strings.Trim(r.resptext, "\"\n")
+	}
+	submit(tq.qnid, "ans-text=Paris")
+	// The final has no answer key, so the quizmaster marks the wager.
+	final := tq.addQuestion(t, c, "qn-title=Q2&qn-body=Double+or+nothing&qn-type=text&qn-wager=true")
+	tq.setActive(t, c, final)
+	submit(final, "ans-text=Rome&ans-wager=8")
+	ansid := submit(final, "ans-text=Roma&ans-wager=8")
+
+	wagerScore := func() int64 {
+		t.Helper()
+		id, _ := strconv.ParseInt(tq.qzid, 10, 64)
+		qz, err := p.GetQuiz(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ansmap, err := p.GetAllAnswersForSetOfQuestions(qz.GetQuestions())
+		if err != nil {
+			t.Fatal(err)
+		}
+		board := getScoreboard(qz, ansmap)
+		if len(board.PAndScore) != 1 || len(board.PAndScore[0].Score) != 2 {
+			t.Fatalf("want one participant with two scores, got %+v", board.PAndScore)
+		}
+		return board.PAndScore[0].Score[1]
+	}
+	if got := wagerScore(); got != 0 {
+		t.Errorf("want nothing won or lost on a wager that is not marked, got %v", got)
+	}
+	r := callController("POST", fmt.Sprintf("/api/quizmaster/question/%v/savescores", final),
+		fmt.Sprintf("ans-%v-score=0", ansid), tq.qmCookie, map[string]string{"questionid": final}, c.SaveScores)
+	if r.statuscode != 200 {
+		t.Fatalf("Failed to save scores. HTTP %v. %v", r.statuscode, r.resptext)
+	}
+	if got := wagerScore(); got != -8 {
+		t.Errorf("want the wager of 8 lost when marked 0, got %v", got)
+	}
+}
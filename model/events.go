@@ -0,0 +1,131 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"sync"
+)
+
+// Event is something that changed in a quiz. Persistence publishes events
+// only after the write that caused them has been committed.
+type Event interface {
+	// QuizID is the quiz that the event belongs to.
+	QuizID() int64
+}
+
+// quizEvent is embedded in all events to tell which quiz they belong to.
+type quizEvent struct {
+	quizID int64
+}
+
+// QuizID implements Event.
+func (e quizEvent) QuizID() int64 { return e.quizID }
+
+// QuizSaved is published whenever the quiz proto is written.
+type QuizSaved struct {
+	quizEvent
+	Quiz *Quiz
+}
+
+// QuestionActivated is published when the live question of a quiz changes.
+type QuestionActivated struct {
+	quizEvent
+	QuestionID int64
+}
+
+// AcceptingResponsesChanged is published when a quiz starts or stops accepting responses.
+type AcceptingResponsesChanged struct {
+	quizEvent
+	AcceptingResponses bool
+}
+
+// AnswerRevealedChanged is published when the solution to the live question is shown or hidden.
+type AnswerRevealedChanged struct {
+	quizEvent
+	AnswerRevealed bool
+}
+
+// QuestionSaved is published when a question is created or updated.
+type QuestionSaved struct {
+	quizEvent
+	Question *Question
+}
+
+// AnswerSubmitted is published when a participant creates or updates an answer.
+type AnswerSubmitted struct {
+	quizEvent
+	Answer *Answer
+}
+
+// ScoresSaved is published when points are stored for a set of answers in a quiz.
+type ScoresSaved struct {
+	quizEvent
+	Answers []*Answer
+}
+
+// ParticipantJoined is published when a participant registers for a quiz, or changes their profile name.
+type ParticipantJoined struct {
+	quizEvent
+	Participant *ParticipantProfile
+}
+
+// EventBus delivers quiz events to subscribers. The zero value is ready to use.
+type EventBus struct {
+	mu     sync.RWMutex
+	nextID int
+	subs   map[int]eventSubscription
+}
+
+type eventSubscription struct {
+	quizID int64
+	fn     func(Event)
+}
+
+// Subscribe calls fn for every event on the given quiz, or on every quiz if quizID is 0.
+// fn is called on the goroutine that made the write, so it should return quickly.
+// The returned function cancels the subscription.
+func (b *EventBus) Subscribe(quizID int64, fn func(Event)) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs == nil {
+		b.subs = make(map[int]eventSubscription)
+	}
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = eventSubscription{quizID: quizID, fn: fn}
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.subs, id)
+	}
+}
+
+func (b *EventBus) publish(events ...Event) {
+	for _, e := range events {
+		// Collect the subscribers first, so that they are free to
+		// subscribe or unsubscribe while handling the event.
+		b.mu.RLock()
+		fns := make([]func(Event), 0, len(b.subs))
+		for _, s := range b.subs {
+			if s.quizID == 0 || s.quizID == e.QuizID() {
+				fns = append(fns, s.fn)
+			}
+		}
+		b.mu.RUnlock()
+		for _, fn := range fns {
+			fn(e)
+		}
+	}
+}
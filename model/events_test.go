@@ -0,0 +1,193 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestEventsArePublishedAfterWrites(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	uid, err := p.NewGuestLogin("cookie-cookie-ev", time.Now().Unix()+10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qzid, err := p.CreateQuiz(&Quiz{
+		Quizmasters: []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherQzid, err := p.CreateQuiz(&Quiz{
+		Quizmasters: []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []Event
+	cancel := p.Events.Subscribe(int64(qzid), func(e Event) {
+		got = append(got, e)
+	})
+	defer cancel()
+	var all int
+	cancelAll := p.Events.Subscribe(0, func(e Event) {
+		all++
+	})
+	defer cancelAll()
+
+	qnid, err := p.CreateQuestion(&Question{QuizId: proto.Int64(int64(qzid)), Title: proto.String("Q")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.CreateQuestion(&Question{QuizId: proto.Int64(int64(otherQzid))}); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RegisterParticipant(int64(qzid), 42, "Party"); err != nil {
+		t.Fatal(err)
+	}
+	qz, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	qz.LiveQuestionId = proto.Int64(int64(qnid))
+	qz.AcceptingResponses = proto.Bool(true)
+	if err := p.SaveQuiz(qz); err != nil {
+		t.Fatal(err)
+	}
+	ansid, err := p.CreateAnswer(&Answer{
+		QuestionId: proto.Int64(int64(qnid)),
+		SolverId:   proto.Int64(42),
+		AnsText:    proto.String("Paris"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Someone else cannot update the answer, and nothing should be published for that.
+	if err := p.UpdateAnswer(&Answer{
+		Id:         proto.Int64(int64(ansid)),
+		QuestionId: proto.Int64(int64(qnid)),
+		SolverId:   proto.Int64(43),
+	}); err == nil {
+		t.Fatalf("answer was updated by someone else")
+	}
+	ans, err := p.GetAnswerByID(ansid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ans.PointsAwarded = proto.Int64(10)
+	if err := p.SaveMultipleAnswers([]*Answer{ans}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var types []string
+	for _, e := range got {
+		if e.QuizID() != int64(qzid) {
+			t.Errorf("got an event for quiz %v, want only quiz %v", e.QuizID(), qzid)
+		}
+		switch e := e.(type) {
+		case *QuestionSaved:
+			types = append(types, "QuestionSaved")
+		case *ParticipantJoined:
+			types = append(types, "ParticipantJoined")
+			if e.Participant.GetProfileName() != "Party" {
+				t.Errorf("wrong participant joined: %v", e.Participant)
+			}
+		case *QuizSaved:
+			types = append(types, "QuizSaved")
+		case *QuestionActivated:
+			types = append(types, "QuestionActivated")
+			if e.QuestionID != int64(qnid) {
+				t.Errorf("wrong question activated. want %v, got %v", qnid, e.QuestionID)
+			}
+		case *AcceptingResponsesChanged:
+			types = append(types, "AcceptingResponsesChanged")
+		case *AnswerSubmitted:
+			types = append(types, "AnswerSubmitted")
+			if e.Answer.GetId() != int64(ansid) {
+				t.Errorf("wrong answer submitted. want %v, got %v", ansid, e.Answer.GetId())
+			}
+		case *ScoresSaved:
+			types = append(types, "ScoresSaved")
+			if len(e.Answers) != 1 || e.Answers[0].GetPointsAwarded() != 10 {
+				t.Errorf("wrong scores saved: %v", e.Answers)
+			}
+		}
+	}
+	want := []string{"QuestionSaved", "ParticipantJoined", "QuizSaved", "QuestionActivated",
+		"AcceptingResponsesChanged", "AnswerSubmitted", "ScoresSaved"}
+	if len(types) != len(want) {
+		t.Fatalf("wrong events. want %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Errorf("wrong event %v. want %v, got %v", i, want[i], types[i])
+		}
+	}
+	if all != len(want)+1 {
+		t.Errorf("subscriber to all quizzes got %v events, want %v", all, len(want)+1)
+	}
+}
+
+func TestRoundChangesArePublished(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	uid, err := p.NewGuestLogin("cookie-cookie-rd", time.Now().Unix()+10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qzid, err := p.CreateQuiz(&Quiz{
+		Quizmasters: []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []*Quiz
+	cancel := p.Events.Subscribe(int64(qzid), func(e Event) {
+		if e, ok := e.(*QuizSaved); ok {
+			saved = append(saved, e.Quiz)
+		}
+	})
+	defer cancel()
+
+	rdid, err := p.SaveRound(int64(qzid), &Round{Title: proto.String("Music")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	qz, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	qz.LiveRoundId = proto.Int64(rdid)
+	if err := p.SaveQuiz(qz); err != nil {
+		t.Fatal(err)
+	}
+	// Deleting the live round takes its title card down.
+	if err := p.DeleteRound(int64(qzid), rdid); err != nil {
+		t.Fatal(err)
+	}
+	if len(saved) != 3 || len(saved[0].GetRounds()) != 1 || saved[1].GetLiveRoundId() != rdid ||
+		len(saved[2].GetRounds()) != 0 || saved[2].GetLiveRoundId() != 0 {
+		t.Errorf("want the quiz published after each change to the rounds, got %v", saved)
+	}
+}
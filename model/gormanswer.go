@@ -37,6 +37,7 @@ type GormAnswer struct {
 // CreateAnswer stores a new answer to a question in the db
 func (p *Persistence) CreateAnswer(ans *Answer) (uint, error) {
 	var ansid uint
+	var qzid int64
 	txerr := p.db.Transaction(func(tx *gorm.DB) error {
 		var ga GormAnswer
 		ga.GormQuestionID = uint(ans.GetQuestionId())
@@ -49,12 +50,14 @@ func (p *Persistence) CreateAnswer(ans *Answer) (uint, error) {
 			return err
 		}
 
+		var oldPoints *int64
 		if ga.ID != 0 {
 			oldAns, err := getAnswerFromGormAnswer(ga)
 			if err != nil {
 				return err
 			}
-			ans.PointsAwarded = proto.Int64(oldAns.GetPointsAwarded())
+			oldPoints = oldAns.PointsAwarded
+			keepPoints(ans, oldAns)
 		}
 
 		b, err := proto.Marshal(ans)
@@ -73,14 +76,27 @@ func (p *Persistence) CreateAnswer(ans *Answer) (uint, error) {
 			}
 		}
 		ansid = ga.ID
-		return nil
+		if qzid, err = getQuizIDForQuestionID(tx, ga.GormQuestionID); err != nil {
+			return err
+		}
+		saved := proto.Clone(ans).(*Answer)
+		saved.Id = proto.Int64(int64(ansid))
+		return recordScoreChange(tx, qzid, saved, oldPoints, nil)
 	})
-	return ansid, txerr
+	if txerr != nil {
+		return ansid, txerr
+	}
+	saved := proto.Clone(ans).(*Answer)
+	saved.Id = proto.Int64(int64(ansid))
+	p.Events.publish(&AnswerSubmitted{quizEvent{qzid}, saved})
+	return ansid, nil
 }
 
-// UpdateAnswer stores an updated answer in the db (Note: scores will be preserved)
+// UpdateAnswer stores an updated answer in the db (Note: scores will be preserved,
+// unless the updated answer has been graded already and the quizmaster did not set them by hand)
 func (p *Persistence) UpdateAnswer(ans *Answer) error {
-	return p.db.Transaction(func(tx *gorm.DB) error {
+	var qzid int64
+	err := p.db.Transaction(func(tx *gorm.DB) error {
 		var ga GormAnswer
 		if err := tx.First(&ga, uint(ans.GetId())).Error; err != nil {
 			return err
@@ -94,13 +110,38 @@ func (p *Persistence) UpdateAnswer(ans *Answer) error {
 				"unauthorized update qnid: %v belongs to %v and not current user %v",
 				oldAns.GetId(), oldAns.GetSolverId(), ans.GetSolverId())
 		}
-		ans.PointsAwarded = proto.Int64(oldAns.GetPointsAwarded())
+		if oldAns.GetQuestionId() != ans.GetQuestionId() {
+			return fmt.Errorf("answer %v is to question %v and not to question %v",
+				oldAns.GetId(), oldAns.GetQuestionId(), ans.GetQuestionId())
+		}
+		keepPoints(ans, oldAns)
 		nga, err := getGormAnswerFromAnswer(ans)
 		if err != nil {
 			return err
 		}
-		return tx.Save(nga).Error
+		if err := tx.Save(nga).Error; err != nil {
+			return err
+		}
+		if qzid, err = getQuizIDForQuestionID(tx, nga.GormQuestionID); err != nil {
+			return err
+		}
+		return recordScoreChange(tx, qzid, ans, oldAns.PointsAwarded, nil)
 	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(&AnswerSubmitted{quizEvent{qzid}, proto.Clone(ans).(*Answer)})
+	return nil
+}
+
+// keepPoints carries the points of the stored answer over to its resubmission if the new
+// answer has not been graded, or if the quizmaster set the points by hand.
+func keepPoints(ans *Answer, oldAns *Answer) {
+	if ans.PointsAwarded == nil || oldAns.GetPointsOverridden() {
+		// An answer that was not graded stays that way, rather than getting 0 points.
+		ans.PointsAwarded = oldAns.PointsAwarded
+		ans.PointsOverridden = oldAns.PointsOverridden
+	}
 }
 
 // GetAnswerByID fetches an answer by a given ID
@@ -143,20 +184,60 @@ func (p *Persistence) GetAllAnswersToQuestionID(id uint) ([]*Answer, error) {
 }
 
 // SaveMultipleAnswers saves multiple answers to the db. This is useful when
-// storing scores, for example.
-func (p *Persistence) SaveMultipleAnswers(sansa []*Answer) error {
-	return p.db.Transaction(func(tx *gorm.DB) error {
+// storing scores, for example. Every change to the points is logged as made by
+// changedBy, which is nil when the answers were graded automatically.
+func (p *Persistence) SaveMultipleAnswers(sansa []*Answer, changedBy *User) error {
+	// The answers could belong to more than one quiz, so they are grouped
+	// into one event per quiz.
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		byQuiz := make(map[int64]*ScoresSaved)
+		qnToQuiz := make(map[uint]int64)
 		for _, ans := range sansa {
 			ga, err := getGormAnswerFromAnswer(ans)
 			if err != nil {
 				return err
 			}
+			var oldPoints *int64
+			if ga.ID != 0 {
+				var old GormAnswer
+				if err := tx.First(&old, ga.ID).Error; err != nil {
+					return err
+				}
+				oldAns, err := getAnswerFromGormAnswer(old)
+				if err != nil {
+					return err
+				}
+				oldPoints = oldAns.PointsAwarded
+			}
 			if err := tx.Save(ga).Error; err != nil {
 				return err
 			}
+			qzid, ok := qnToQuiz[ga.GormQuestionID]
+			if !ok {
+				if qzid, err = getQuizIDForQuestionID(tx, ga.GormQuestionID); err != nil {
+					return err
+				}
+				qnToQuiz[ga.GormQuestionID] = qzid
+			}
+			if err := recordScoreChange(tx, qzid, ans, oldPoints, changedBy); err != nil {
+				return err
+			}
+			e, ok := byQuiz[qzid]
+			if !ok {
+				e = &ScoresSaved{quizEvent: quizEvent{qzid}}
+				byQuiz[qzid] = e
+				events = append(events, e)
+			}
+			e.Answers = append(e.Answers, proto.Clone(ans).(*Answer))
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
 }
 
 // GetAllAnswersForSetOfQuestions gets all the answers for a set of questions.
@@ -181,6 +262,15 @@ func (p *Persistence) GetAllAnswersForSetOfQuestions(qns []*Question) (map[*Ques
 	return board, nil
 }
 
+func getQuizIDForQuestionID(tx *gorm.DB, qnid uint) (int64, error) {
+	var qn GormQuestion
+	// Deleted questions still belong to their quiz.
+	if err := tx.Unscoped().First(&qn, qnid).Error; err != nil {
+		return 0, err
+	}
+	return int64(qn.GormQuizID), nil
+}
+
 func getAnswerFromGormAnswer(ga GormAnswer) (*Answer, error) {
 	var ans Answer
 	if err := proto.Unmarshal(ga.ProtoData, &ans); err != nil {
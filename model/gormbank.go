@@ -0,0 +1,274 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// GormBankQuestion is the persisted version of the BankQuestion proto
+type GormBankQuestion struct {
+	gorm.Model
+	// OwnerID is the user whose bank the question is in.
+	OwnerID uint `gorm:"index"`
+	// ProtoData contains the serialized BankQuestion proto
+	ProtoData []byte
+}
+
+// GetBankQuestion returns the bank question with the given ID.
+func (p *Persistence) GetBankQuestion(id uint) (*BankQuestion, error) {
+	var gbq GormBankQuestion
+	if err := p.db.First(&gbq, id).Error; err != nil {
+		return nil, err
+	}
+	return getBankQuestionFromGormBankQuestion(&gbq)
+}
+
+// GetBankQuestionsForOwner returns the questions in the bank of the user, oldest first.
+func (p *Persistence) GetBankQuestionsForOwner(ownerID int64) ([]*BankQuestion, error) {
+	var gbqs []GormBankQuestion
+	if err := p.db.Where("owner_id = ?", ownerID).Order("id").Find(&gbqs).Error; err != nil {
+		return nil, err
+	}
+	bqs := make([]*BankQuestion, 0, len(gbqs))
+	for _, gbq := range gbqs {
+		bq, err := getBankQuestionFromGormBankQuestion(&gbq)
+		if err != nil {
+			return nil, err
+		}
+		bqs = append(bqs, bq)
+	}
+	return bqs, nil
+}
+
+// SaveQuestionToBank adds a copy of the quiz question to the bank of the owner, and returns the
+// ID of the bank question. The quiz question remembers where it was saved, but is not linked to it.
+func (p *Persistence) SaveQuestionToBank(qnid uint, ownerID int64, tags []string) (uint, error) {
+	var bankID uint
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gqn GormQuestion
+		if err := tx.First(&gqn, qnid).Error; err != nil {
+			return err
+		}
+		qn, err := getQuestionFromGormQuestion(&gqn)
+		if err != nil {
+			return err
+		}
+		bq := BankQuestion{
+			OwnerId:       proto.Int64(ownerID),
+			Question:      getBankContent(qn),
+			Tags:          tags,
+			UsedInQuizIds: []int64{int64(gqn.GormQuizID)},
+		}
+		gbq := GormBankQuestion{OwnerID: uint(ownerID)}
+		if gbq.ProtoData, err = proto.Marshal(&bq); err != nil {
+			return err
+		}
+		if err := tx.Create(&gbq).Error; err != nil {
+			return err
+		}
+		bankID = gbq.ID
+		qn.BankQuestionId = proto.Int64(int64(bankID))
+		qn.LinkedToBank = nil
+		gqn.BankQuestionID = bankID
+		if gqn.ProtoData, err = proto.Marshal(qn); err != nil {
+			return err
+		}
+		return tx.Save(&gqn).Error
+	})
+	return bankID, err
+}
+
+// AddBankQuestionToQuiz copies the bank question to the end of the quiz, or links it there if
+// link is set, and records that the bank question was used in the quiz. It returns the ID of
+// the new quiz question.
+func (p *Persistence) AddBankQuestionToQuiz(bankID uint, qzid int64, link bool) (uint, error) {
+	var saved *Question
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gbq GormBankQuestion
+		if err := tx.First(&gbq, bankID).Error; err != nil {
+			return err
+		}
+		bq, err := getBankQuestionFromGormBankQuestion(&gbq)
+		if err != nil {
+			return err
+		}
+		qn := withBankContent(&Question{
+			QuizId:         proto.Int64(qzid),
+			BankQuestionId: proto.Int64(int64(bankID)),
+		}, bq.GetQuestion())
+		if link {
+			qn.LinkedToBank = proto.Bool(true)
+		}
+		qnid, err := createQuestion(tx, qn)
+		if err != nil {
+			return err
+		}
+		qn.Id = proto.Int64(int64(qnid))
+		saved = qn
+		if slices.Contains(bq.GetUsedInQuizIds(), qzid) {
+			return nil
+		}
+		bq.UsedInQuizIds = append(bq.UsedInQuizIds, qzid)
+		return saveGormBankQuestion(tx, &gbq, bq)
+	})
+	if err != nil {
+		return 0, err
+	}
+	p.Events.publish(&QuestionSaved{quizEvent{qzid}, saved})
+	return uint(saved.GetId()), nil
+}
+
+// SaveLinkedQuestion saves a question that is linked to a bank question, and makes the same
+// change to the bank question and to every other question that is linked to it.
+func (p *Persistence) SaveLinkedQuestion(qp *Question) error {
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gbq GormBankQuestion
+		if err := tx.First(&gbq, qp.GetBankQuestionId()).Error; err != nil {
+			return err
+		}
+		bq, err := getBankQuestionFromGormBankQuestion(&gbq)
+		if err != nil {
+			return err
+		}
+		bq.Question = getBankContent(qp)
+		if err := saveGormBankQuestion(tx, &gbq, bq); err != nil {
+			return err
+		}
+		var gqns []GormQuestion
+		if err := tx.Where("bank_question_id = ?", gbq.ID).Find(&gqns).Error; err != nil {
+			return err
+		}
+		for _, gqn := range gqns {
+			qn, err := getQuestionFromGormQuestion(&gqn)
+			if err != nil {
+				return err
+			}
+			qn.QuizId = proto.Int64(int64(gqn.GormQuizID))
+			if qn.GetId() == qp.GetId() {
+				// The saved question may also have moved to another round.
+				qn = qp
+			} else if !qn.GetLinkedToBank() {
+				continue
+			}
+			qn = withBankContent(qn, bq.GetQuestion())
+			if gqn.ProtoData, err = proto.Marshal(qn); err != nil {
+				return err
+			}
+			if err := tx.Model(&gqn).Update("ProtoData", gqn.ProtoData).Error; err != nil {
+				return err
+			}
+			events = append(events, &QuestionSaved{quizEvent{qn.GetQuizId()}, qn})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
+}
+
+// SaveBankQuestionTags replaces the tags of the bank question.
+func (p *Persistence) SaveBankQuestionTags(id uint, tags []string) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		var gbq GormBankQuestion
+		if err := tx.First(&gbq, id).Error; err != nil {
+			return err
+		}
+		bq, err := getBankQuestionFromGormBankQuestion(&gbq)
+		if err != nil {
+			return err
+		}
+		bq.Tags = tags
+		return saveGormBankQuestion(tx, &gbq, bq)
+	})
+}
+
+// DeleteBankQuestion soft-deletes the bank question. The questions that were linked to it
+// become copies, which are no longer changed along with each other.
+func (p *Persistence) DeleteBankQuestion(id uint) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		var gqns []GormQuestion
+		if err := tx.Where("bank_question_id = ?", id).Find(&gqns).Error; err != nil {
+			return err
+		}
+		for _, gqn := range gqns {
+			qn, err := getQuestionFromGormQuestion(&gqn)
+			if err != nil {
+				return err
+			}
+			if !qn.GetLinkedToBank() {
+				continue
+			}
+			qn.LinkedToBank = nil
+			if gqn.ProtoData, err = proto.Marshal(qn); err != nil {
+				return err
+			}
+			if err := tx.Model(&gqn).Update("ProtoData", gqn.ProtoData).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Delete(&GormBankQuestion{}, id).Error
+	})
+}
+
+// getBankContent returns a copy of the question without what ties it to a quiz.
+func getBankContent(qn *Question) *Question {
+	content := proto.Clone(qn).(*Question)
+	content.Id = nil
+	content.QuizId = nil
+	content.RoundId = nil
+	content.BankQuestionId = nil
+	content.LinkedToBank = nil
+	content.Answers = nil
+	return content
+}
+
+// withBankContent returns the bank content in place of the question, keeping what ties the
+// question to its quiz.
+func withBankContent(qn *Question, content *Question) *Question {
+	updated := proto.Clone(content).(*Question)
+	updated.Id = qn.Id
+	updated.QuizId = qn.QuizId
+	updated.RoundId = qn.RoundId
+	updated.BankQuestionId = qn.BankQuestionId
+	updated.LinkedToBank = qn.LinkedToBank
+	updated.Answers = qn.Answers
+	return updated
+}
+
+func saveGormBankQuestion(tx *gorm.DB, gbq *GormBankQuestion, bq *BankQuestion) error {
+	b, err := proto.Marshal(bq)
+	if err != nil {
+		return err
+	}
+	gbq.ProtoData = b
+	return tx.Save(gbq).Error
+}
+
+func getBankQuestionFromGormBankQuestion(gbq *GormBankQuestion) (*BankQuestion, error) {
+	var bq BankQuestion
+	if err := proto.Unmarshal(gbq.ProtoData, &bq); err != nil {
+		return nil, err
+	}
+	bq.Id = proto.Int64(int64(gbq.ID))
+	bq.OwnerId = proto.Int64(int64(gbq.OwnerID))
+	return &bq, nil
+}
@@ -0,0 +1,158 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestQuestionBank(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	uid, err := p.NewGuestLogin("cookie-cookie-bank", time.Now().Unix()+10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newQuiz := func() int64 {
+		t.Helper()
+		qzid, err := p.CreateQuiz(&Quiz{
+			Quizmasters: []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return int64(qzid)
+	}
+	qz1, qz2, qz3 := newQuiz(), newQuiz(), newQuiz()
+	qnid, err := p.CreateQuestion(&Question{
+		QuizId:   proto.Int64(qz1),
+		Title:    proto.String("Capital of France"),
+		RoundId:  proto.Int64(7),
+		Points:   proto.Int64(5),
+		HtmlBody: proto.String("Which city?"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bankID, err := p.SaveQuestionToBank(qnid, int64(uid), []string{"geography"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bq, err := p.GetBankQuestion(bankID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bq.GetOwnerId() != int64(uid) || bq.GetQuestion().GetTitle() != "Capital of France" ||
+		fmt.Sprint(bq.GetUsedInQuizIds()) != fmt.Sprint([]int64{qz1}) {
+		t.Errorf("wrong bank question: %v", bq)
+	}
+	if bq.GetQuestion().QuizId != nil || bq.GetQuestion().RoundId != nil || bq.GetQuestion().Id != nil {
+		t.Errorf("the bank question should not belong to a quiz: %v", bq.GetQuestion())
+	}
+	if qn, err := p.GetQuestionByID(qnid); err != nil || qn.GetBankQuestionId() != int64(bankID) || qn.GetLinkedToBank() {
+		t.Errorf("the quiz question should remember the bank question without being linked: %v, %v", qn, err)
+	}
+
+	copyID, err := p.AddBankQuestionToQuiz(bankID, qz2, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linkID, err := p.AddBankQuestionToQuiz(bankID, qz3, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.AddBankQuestionToQuiz(bankID, qz3, true); err != nil {
+		t.Fatal(err)
+	}
+	if bq, err = p.GetBankQuestion(bankID); err != nil {
+		t.Fatal(err)
+	}
+	if fmt.Sprint(bq.GetUsedInQuizIds()) != fmt.Sprint([]int64{qz1, qz2, qz3}) {
+		t.Errorf("each quiz should be recorded once, got %v", bq.GetUsedInQuizIds())
+	}
+	qz, err := p.GetQuiz(qz3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(qz.GetQuestions()) != 2 || qz.GetQuestions()[0].GetId() != int64(linkID) ||
+		qz.GetQuestions()[0].GetTitle() != "Capital of France" || qz.GetQuestions()[0].GetPoints() != 5 {
+		t.Errorf("the linked question was not added to the quiz: %v", qz.GetQuestions())
+	}
+
+	// Changing a linked question changes the bank and the other linked questions, but not copies.
+	linked, err := p.GetQuestionByID(linkID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	linked.Title = proto.String("Capital of Spain")
+	linked.RoundId = proto.Int64(3)
+	if err := p.SaveLinkedQuestion(linked); err != nil {
+		t.Fatal(err)
+	}
+	if bq, err = p.GetBankQuestion(bankID); err != nil || bq.GetQuestion().GetTitle() != "Capital of Spain" ||
+		bq.GetQuestion().RoundId != nil {
+		t.Errorf("the bank question was not updated: %v, %v", bq, err)
+	}
+	qz, err = p.GetQuiz(qz3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, qn := range qz.GetQuestions() {
+		if qn.GetTitle() != "Capital of Spain" || qn.GetQuizId() != qz3 {
+			t.Errorf("the linked question was not updated: %v", qn)
+		}
+		wantRound := int64(0)
+		if qn.GetId() == int64(linkID) {
+			wantRound = 3
+		}
+		if qn.GetRoundId() != wantRound {
+			t.Errorf("each linked question should keep its own round, want %v: %v", wantRound, qn)
+		}
+	}
+	for _, id := range []uint{qnid, copyID} {
+		if qn, err := p.GetQuestionByID(id); err != nil || qn.GetTitle() != "Capital of France" {
+			t.Errorf("question %v is not linked and should not change: %v, %v", id, qn, err)
+		}
+	}
+
+	if err := p.SaveBankQuestionTags(bankID, []string{"capitals", "europe"}); err != nil {
+		t.Fatal(err)
+	}
+	bqs, err := p.GetBankQuestionsForOwner(int64(uid))
+	if err != nil || len(bqs) != 1 || fmt.Sprint(bqs[0].GetTags()) != "[capitals europe]" {
+		t.Errorf("wrong bank: %v, %v", bqs, err)
+	}
+	if bqs, err := p.GetBankQuestionsForOwner(int64(uid) + 1); err != nil || len(bqs) != 0 {
+		t.Errorf("another user should have an empty bank: %v, %v", bqs, err)
+	}
+
+	// Deleting the bank question turns the linked questions into copies.
+	if err := p.DeleteBankQuestion(bankID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.GetBankQuestion(bankID); err == nil {
+		t.Error("the bank question should be deleted")
+	}
+	if qn, err := p.GetQuestionByID(linkID); err != nil || qn.GetLinkedToBank() || qn.GetTitle() != "Capital of Spain" {
+		t.Errorf("the question should be unlinked and keep its content: %v, %v", qn, err)
+	}
+}
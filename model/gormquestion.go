@@ -15,8 +15,11 @@
 package model
 
 import (
-	"gorm.io/gorm"
+	"errors"
+	"slices"
+
 	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
 )
 
 // GormQuestion is the persisted version of the Question proto
@@ -25,6 +28,8 @@ type GormQuestion struct {
 	// GormQuizID is the quiz id to which this question belongs.
 	// Foreign key reference to the GormQuiz table.
 	GormQuizID uint
+	// BankQuestionID is the question bank entry that this question came from or was saved to, if any.
+	BankQuestionID uint `gorm:"index"`
 	// Answers is the set of responses to this question.
 	Answers []GormAnswer
 	// ProtoData contains the serialized Question proto
@@ -33,15 +38,38 @@ type GormQuestion struct {
 
 // CreateQuestion appends a new question to the quiz. The QuizId field
 // must be populated for this to succeed. The ID field is ignored.
+// The question is added to the end of the question sequence of the quiz.
 func (p *Persistence) CreateQuestion(qp *Question) (uint, error) {
-	var qn GormQuestion
-	qn.GormQuizID = uint(qp.GetQuizId())
+	var qnid uint
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		qnid, err = createQuestion(tx, qp)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	saved := proto.Clone(qp).(*Question)
+	saved.Id = proto.Int64(int64(qnid))
+	p.Events.publish(&QuestionSaved{quizEvent{saved.GetQuizId()}, saved})
+	return qnid, nil
+}
+
+// createQuestion stores a new question and adds it to the end of the question sequence of its quiz.
+func createQuestion(tx *gorm.DB, qp *Question) (uint, error) {
+	qn := GormQuestion{
+		GormQuizID:     uint(qp.GetQuizId()),
+		BankQuestionID: uint(qp.GetBankQuestionId()),
+	}
 	b, err := proto.Marshal(qp)
 	if err != nil {
 		return 0, err
 	}
 	qn.ProtoData = b
-	if err = p.db.Create(&qn).Error; err != nil {
+	if err := tx.Create(&qn).Error; err != nil {
+		return 0, err
+	}
+	if err := updateQuestionSequence(tx, qp.GetQuizId(), nil); err != nil {
 		return 0, err
 	}
 	return qn.ID, nil
@@ -52,12 +80,17 @@ func (p *Persistence) SaveQuestion(qp *Question) error {
 	var qn GormQuestion
 	qn.ID = uint(qp.GetId())
 	qn.GormQuizID = uint(qp.GetQuizId())
+	qn.BankQuestionID = uint(qp.GetBankQuestionId())
 	b, err := proto.Marshal(qp)
 	if err != nil {
 		return err
 	}
 	qn.ProtoData = b
-	return p.db.Save(&qn).Error
+	if err := p.db.Save(&qn).Error; err != nil {
+		return err
+	}
+	p.Events.publish(&QuestionSaved{quizEvent{qp.GetQuizId()}, proto.Clone(qp).(*Question)})
+	return nil
 }
 
 // GetQuestionByID returns the question proto given an ID.
@@ -69,11 +102,64 @@ func (p *Persistence) GetQuestionByID(qnid uint) (*Question, error) {
 	return getQuestionFromGormQuestion(&gqn)
 }
 
-// DeleteQuestion performs a soft delete of the question
+// DeleteQuestion performs a soft delete of the question, and removes it from the
+// question sequence of its quiz.
 func (p *Persistence) DeleteQuestion(qid uint) error {
-	var qn GormQuestion
-	qn.ID = qid
-	return p.db.Delete(&qn).Error
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		var qn GormQuestion
+		if err := tx.First(&qn, qid).Error; err != nil {
+			return err
+		}
+		if err := tx.Delete(&qn).Error; err != nil {
+			return err
+		}
+		return updateQuestionSequence(tx, int64(qn.GormQuizID), nil)
+	})
+}
+
+// ErrWrongQuestionSequence is returned when a new order does not list every question of the quiz once.
+var ErrWrongQuestionSequence = errors.New("the order must list every question of the quiz exactly once")
+
+// ReorderQuestions changes the order in which the questions of the quiz are asked.
+// qnids must contain each question ID of the quiz exactly once.
+func (p *Persistence) ReorderQuestions(qzid int64, qnids []int64) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		return updateQuestionSequence(tx, qzid, qnids)
+	})
+}
+
+// updateQuestionSequence rewrites the question sequence of the quiz so that it holds
+// exactly the questions that the quiz has now. If qnids is nil, the current order is kept,
+// with any questions missing from the sequence at the end.
+// Otherwise qnids is the new order, and must be a permutation of the questions.
+func updateQuestionSequence(tx *gorm.DB, qzid int64, qnids []int64) error {
+	var gq GormQuiz
+	if err := tx.Preload("GormQuestions").First(&gq, qzid).Error; err != nil {
+		return err
+	}
+	qz, err := getQuizFromGormQuiz(&gq)
+	if err != nil {
+		return err
+	}
+	current := make([]int64, 0, len(qz.GetQuestions()))
+	for _, qn := range qz.GetQuestions() {
+		current = append(current, qn.GetId())
+	}
+	if qnids == nil {
+		qnids = current
+	} else {
+		want := slices.Sorted(slices.Values(qnids))
+		slices.Sort(current)
+		if !slices.Equal(want, current) {
+			return ErrWrongQuestionSequence
+		}
+	}
+	qz.QuestionSequence = qnids
+	qz.Questions = nil
+	if gq.ProtoData, err = proto.Marshal(qz); err != nil {
+		return err
+	}
+	return tx.Omit("GormQuestions").Save(&gq).Error
 }
 
 func getQuestionFromGormQuestion(gqn *GormQuestion) (*Question, error) {
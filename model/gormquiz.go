@@ -15,7 +15,10 @@
 package model
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"slices"
 
 	"google.golang.org/protobuf/proto"
 	"gorm.io/gorm"
@@ -113,7 +116,120 @@ func (p *Persistence) SaveQuiz(q *Quiz) error {
 	if err != nil {
 		return err
 	}
-	return p.db.Save(&gq).Error
+	var events []Event
+	err = p.db.Transaction(func(tx *gorm.DB) error {
+		var old GormQuiz
+		if err := tx.First(&old, gq.ID).Error; err != nil {
+			return err
+		}
+		oldq, err := getQuizFromGormQuiz(&old)
+		if err != nil {
+			return err
+		}
+		// The question sequence, the rounds and the participants have their own methods to change
+		// them, so that saving a quiz that was loaded earlier does not undo those changes.
+		q1.QuestionSequence = oldq.QuestionSequence
+		q1.Rounds = oldq.Rounds
+		q1.LastRoundId = oldq.LastRoundId
+		q1.Participants = oldq.Participants
+		if gq, err = getGormQuizFromQuiz(q1); err != nil {
+			return err
+		}
+		if err := tx.Save(&gq).Error; err != nil {
+			return err
+		}
+		events = getQuizChangeEvents(oldq, q1)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
+}
+
+// CloseResponsesAtDeadline stops the quiz from accepting responses, but only if it
+// still has the given response deadline. This is an atomic read-modify-write of the quiz proto,
+// so that it does not undo a question that was activated in the meantime.
+func (p *Persistence) CloseResponsesAtDeadline(qzid int64, deadlineMs int64) error {
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gq GormQuiz
+		if err := tx.First(&gq, qzid).Error; err != nil {
+			return err
+		}
+		oldq, err := getQuizFromGormQuiz(&gq)
+		if err != nil {
+			return err
+		}
+		if !oldq.GetAcceptingResponses() || oldq.GetResponseDeadlineMs() != deadlineMs {
+			return nil
+		}
+		qz := proto.Clone(oldq).(*Quiz)
+		qz.AcceptingResponses = proto.Bool(false)
+		if gq.ProtoData, err = proto.Marshal(qz); err != nil {
+			return err
+		}
+		if err := tx.Save(&gq).Error; err != nil {
+			return err
+		}
+		events = getQuizChangeEvents(oldq, qz)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
+}
+
+// StartScheduledQuiz calls start with the first question of the quiz and saves the result,
+// but only if the quiz is still set to start automatically at startTime and no question
+// has been activated yet. This is an atomic read-modify-write of the quiz proto.
+func (p *Persistence) StartScheduledQuiz(qzid int64, startTime int64, start func(qz *Quiz, qn *Question)) error {
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gq GormQuiz
+		if err := tx.Preload("GormQuestions").First(&gq, qzid).Error; err != nil {
+			return err
+		}
+		oldq, err := getQuizFromGormQuiz(&gq)
+		if err != nil {
+			return err
+		}
+		if !oldq.GetAutoStart() || oldq.GetExpectedStartTime() != startTime || oldq.GetLiveQuestionId() != 0 {
+			return nil
+		}
+		qn := getFirstQuestion(oldq)
+		if qn == nil {
+			return nil
+		}
+		qz := proto.Clone(oldq).(*Quiz)
+		start(qz, qn)
+		qz.Questions = nil
+		if gq.ProtoData, err = proto.Marshal(qz); err != nil {
+			return err
+		}
+		if err := tx.Omit("GormQuestions").Save(&gq).Error; err != nil {
+			return err
+		}
+		events = getQuizChangeEvents(oldq, qz)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
+}
+
+// getFirstQuestion returns the question that the quiz opens with, or nil if it has none.
+// The questions of the quiz must be populated.
+func getFirstQuestion(qz *Quiz) *Question {
+	if len(qz.GetQuestions()) == 0 {
+		return nil
+	}
+	return qz.GetQuestions()[0]
 }
 
 // DeleteQuiz soft-deletes a quiz
@@ -137,7 +253,8 @@ func (p *Persistence) ReinstateQuiz(qzid int64) error {
 
 // SaveQuizMetadata stores the metadata of the quiz without affecting other fields.
 func (p *Persistence) SaveQuizMetadata(qz *Quiz) error {
-	return p.db.Transaction(func(tx *gorm.DB) error {
+	var saved *Quiz
+	err := p.db.Transaction(func(tx *gorm.DB) error {
 		var gq GormQuiz
 		if err := tx.First(&gq, uint(qz.GetId())).Error; err != nil {
 			return err
@@ -148,12 +265,26 @@ func (p *Persistence) SaveQuizMetadata(qz *Quiz) error {
 		}
 		qzo.Title = proto.String(qz.GetTitle())
 		qzo.HtmlDescription = proto.String(qz.GetHtmlDescription())
+		qzo.ExpectedStartTime = qz.ExpectedStartTime
+		qzo.AutoStart = qz.AutoStart
+		qzo.SpeedBonus = qz.SpeedBonus
+		qzo.PointScale = qz.PointScale
+		qzo.JokerEnabled = qz.JokerEnabled
 		gq2, err := getGormQuizFromQuiz(qzo)
 		if err != nil {
 			return nil
 		}
-		return tx.Save(gq2).Error
+		if err := tx.Save(gq2).Error; err != nil {
+			return err
+		}
+		saved = qzo
+		return nil
 	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(&QuizSaved{quizEvent{saved.GetId()}, saved})
+	return nil
 }
 
 // GetAllQuizzes returns all the quizzes in the database (without questions populated)
@@ -176,7 +307,8 @@ func (p *Persistence) GetAllQuizzes() ([]*Quiz, error) {
 // RegisterParticipant adds a user to the quiz as a participant if needed
 // This is an atomic read-modify-write of the quiz proto
 func (p *Persistence) RegisterParticipant(qid int64, userID int64, profileName string) error {
-	return p.db.Transaction(func(tx *gorm.DB) error {
+	var joined *ParticipantProfile
+	err := p.db.Transaction(func(tx *gorm.DB) error {
 		var gq GormQuiz
 		if err := tx.First(&gq, qid).Error; err != nil {
 			return err
@@ -210,8 +342,155 @@ func (p *Persistence) RegisterParticipant(qid int64, userID int64, profileName s
 		if err != nil {
 			return err
 		}
+		joined = proto.Clone(pp).(*ParticipantProfile)
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(&ParticipantJoined{quizEvent{qid}, joined})
+	return nil
+}
+
+// ErrJokerPlayed is returned when a participant tries to play a second joker.
+var ErrJokerPlayed = errors.New("the joker has already been played")
+
+// PlayJoker records that the participant played their joker on the round.
+// This is an atomic read-modify-write of the quiz proto, so a joker can only be played once.
+func (p *Persistence) PlayJoker(qid int64, userID int64, rdid int64) error {
+	return p.db.Transaction(func(tx *gorm.DB) error {
+		var gq GormQuiz
+		if err := tx.First(&gq, qid).Error; err != nil {
+			return err
+		}
+		qp, err := getQuizFromGormQuiz(&gq)
+		if err != nil {
+			return err
+		}
+		var pp *ParticipantProfile
+		for _, v := range qp.GetParticipants() {
+			if v.GetUserId() == userID {
+				pp = v
+				break
+			}
+		}
+		if pp == nil {
+			return fmt.Errorf("user %v is not a participant of quiz %v", userID, qid)
+		}
+		if pp.GetJokerRoundId() != 0 {
+			return ErrJokerPlayed
+		}
+		pp.JokerRoundId = proto.Int64(rdid)
+		b, err := proto.Marshal(qp)
+		if err != nil {
+			return err
+		}
+		gq.ProtoData = b
+		return tx.Save(&gq).Error
+	})
+}
+
+// ErrRoundNotFound is returned when the quiz has no round with the given ID.
+var ErrRoundNotFound = errors.New("the quiz has no such round")
+
+// SaveRound adds the round to the end of the quiz if it has no ID, and otherwise replaces
+// the round with the same ID. It returns the ID of the round.
+// This is an atomic read-modify-write of the quiz proto.
+func (p *Persistence) SaveRound(qzid int64, rd *Round) (int64, error) {
+	var rdid int64
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gq GormQuiz
+		if err := tx.First(&gq, qzid).Error; err != nil {
+			return err
+		}
+		oldq, err := getQuizFromGormQuiz(&gq)
+		if err != nil {
+			return err
+		}
+		qz := proto.Clone(oldq).(*Quiz)
+		saved := proto.Clone(rd).(*Round)
+		if rd.GetId() == 0 {
+			rdid = qz.GetLastRoundId() + 1
+			qz.LastRoundId = proto.Int64(rdid)
+			saved.Id = proto.Int64(rdid)
+			qz.Rounds = append(qz.Rounds, saved)
+		} else {
+			i := slices.IndexFunc(qz.GetRounds(), func(v *Round) bool { return v.GetId() == rd.GetId() })
+			if i < 0 {
+				return ErrRoundNotFound
+			}
+			rdid = rd.GetId()
+			qz.Rounds[i] = saved
+		}
+		if gq.ProtoData, err = proto.Marshal(qz); err != nil {
+			return err
+		}
+		if err := tx.Save(&gq).Error; err != nil {
+			return err
+		}
+		events = getQuizChangeEvents(oldq, qz)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	p.Events.publish(events...)
+	return rdid, nil
+}
+
+// DeleteRound removes the round from the quiz. Its questions stay in the quiz, but are no
+// longer part of any round.
+func (p *Persistence) DeleteRound(qzid int64, rdid int64) error {
+	var events []Event
+	err := p.db.Transaction(func(tx *gorm.DB) error {
+		var gq GormQuiz
+		if err := tx.First(&gq, qzid).Error; err != nil {
+			return err
+		}
+		oldq, err := getQuizFromGormQuiz(&gq)
+		if err != nil {
+			return err
+		}
+		qz := proto.Clone(oldq).(*Quiz)
+		i := slices.IndexFunc(qz.GetRounds(), func(v *Round) bool { return v.GetId() == rdid })
+		if i < 0 {
+			return ErrRoundNotFound
+		}
+		qz.Rounds = slices.Delete(qz.Rounds, i, i+1)
+		if qz.GetLiveRoundId() == rdid {
+			qz.LiveRoundId = nil
+		}
+		if gq.ProtoData, err = proto.Marshal(qz); err != nil {
+			return err
+		}
+		if err := tx.Save(&gq).Error; err != nil {
+			return err
+		}
+		events = getQuizChangeEvents(oldq, qz)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	p.Events.publish(events...)
+	return nil
+}
+
+// getQuizChangeEvents returns the events describing the change from the old to the new quiz.
+func getQuizChangeEvents(oldq *Quiz, newq *Quiz) []Event {
+	qe := quizEvent{newq.GetId()}
+	events := []Event{&QuizSaved{qe, newq}}
+	if oldq.GetLiveQuestionId() != newq.GetLiveQuestionId() {
+		events = append(events, &QuestionActivated{qe, newq.GetLiveQuestionId()})
+	}
+	if oldq.GetAcceptingResponses() != newq.GetAcceptingResponses() {
+		events = append(events, &AcceptingResponsesChanged{qe, newq.GetAcceptingResponses()})
+	}
+	if oldq.GetAnswerRevealed() != newq.GetAnswerRevealed() {
+		events = append(events, &AnswerRevealedChanged{qe, newq.GetAnswerRevealed()})
+	}
+	return events
 }
 
 func getQuizFromGormQuiz(gq *GormQuiz) (*Quiz, error) {
@@ -231,9 +510,32 @@ func getQuizFromGormQuiz(gq *GormQuiz) (*Quiz, error) {
 		qp.QuizId = proto.Int64(int64(qn.GormQuizID))
 		q.Questions = append(q.Questions, &qp)
 	}
+	sortQuestions(&q)
 	return &q, nil
 }
 
+// sortQuestions puts the questions of the quiz in the order of its question sequence.
+// Questions that are missing from the sequence keep their relative order, after the others.
+func sortQuestions(qz *Quiz) {
+	pos := make(map[int64]int)
+	for i, id := range qz.GetQuestionSequence() {
+		pos[id] = i
+	}
+	slices.SortStableFunc(qz.Questions, func(a, b *Question) int {
+		pa, oka := pos[a.GetId()]
+		pb, okb := pos[b.GetId()]
+		switch {
+		case oka && okb:
+			return cmp.Compare(pa, pb)
+		case oka:
+			return -1
+		case okb:
+			return 1
+		}
+		return 0
+	})
+}
+
 func getGormQuizFromQuiz(qz *Quiz) (*GormQuiz, error) {
 	b, err := proto.Marshal(qz)
 	if err != nil {
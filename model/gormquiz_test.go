@@ -15,6 +15,8 @@
 package model
 
 import (
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -69,3 +71,120 @@ func TestQuizBasicOperations(t *testing.T) {
 		t.Errorf("wrong title. want %v, got %v.", qz.GetTitle(), ti)
 	}
 }
+
+func TestQuestionSequence(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	uid, err := p.NewGuestLogin("cookie-cookie-sq", time.Now().Unix()+10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qzid, err := p.CreateQuiz(&Quiz{
+		Quizmasters: []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// A question stored before the quiz kept a sequence is asked in the order it was added.
+	if err := p.db.Create(&GormQuestion{GormQuizID: qzid}).Error; err != nil {
+		t.Fatal(err)
+	}
+	for range 3 {
+		if _, err := p.CreateQuestion(&Question{QuizId: proto.Int64(int64(qzid))}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkOrder := func(want ...int64) {
+		t.Helper()
+		qz, err := p.GetQuiz(int64(qzid))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []int64
+		for _, qn := range qz.GetQuestions() {
+			got = append(got, qn.GetId())
+		}
+		if fmt.Sprint(got) != fmt.Sprint(want) || fmt.Sprint(qz.GetQuestionSequence()) != fmt.Sprint(want) {
+			t.Errorf("wrong order. want %v, got questions %v and sequence %v", want, got, qz.GetQuestionSequence())
+		}
+	}
+	checkOrder(1, 2, 3, 4)
+
+	stale, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.ReorderQuestions(int64(qzid), []int64{3, 1, 4, 2}); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(3, 1, 4, 2)
+	// Saving a copy of the quiz from before the reorder keeps the new order.
+	stale.Title = proto.String("Renamed")
+	if err := p.SaveQuiz(stale); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(3, 1, 4, 2)
+
+	for _, bad := range [][]int64{{3, 1, 4}, {3, 1, 4, 4}, {3, 1, 4, 2, 5}, {}} {
+		if err := p.ReorderQuestions(int64(qzid), bad); !errors.Is(err, ErrWrongQuestionSequence) {
+			t.Errorf("reorder to %v: want ErrWrongQuestionSequence, got %v", bad, err)
+		}
+	}
+	checkOrder(3, 1, 4, 2)
+
+	if err := p.DeleteQuestion(1); err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(3, 4, 2)
+	qnid, err := p.CreateQuestion(&Question{QuizId: proto.Int64(int64(qzid))})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkOrder(3, 4, 2, int64(qnid))
+}
+
+func TestSaveQuizKeepsParticipants(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+	uid, err := p.NewGuestLogin("cookie-cookie-pp", time.Now().Unix()+10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	qzid, err := p.CreateQuiz(&Quiz{
+		Quizmasters:  []*QuizmasterProfile{{UserId: proto.Int64(int64(uid))}},
+		JokerEnabled: proto.Bool(true),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RegisterParticipant(int64(qzid), 7, "Owls"); err != nil {
+		t.Fatal(err)
+	}
+	stale, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.RegisterParticipant(int64(qzid), 8, "Larks"); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.PlayJoker(int64(qzid), 7, 2); err != nil {
+		t.Fatal(err)
+	}
+	// Saving a copy of the quiz from before keeps the new participant and the joker.
+	stale.AcceptingResponses = proto.Bool(true)
+	if err := p.SaveQuiz(stale); err != nil {
+		t.Fatal(err)
+	}
+	qz, err := p.GetQuizWithoutQuestions(int64(qzid))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pps := qz.GetParticipants()
+	if len(pps) != 2 || pps[0].GetJokerRoundId() != 2 || pps[1].GetProfileName() != "Larks" || !qz.GetAcceptingResponses() {
+		t.Errorf("want both participants and the joker kept, got %v", qz)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// GormScoreChange is the persisted version of the ScoreChange proto. Rows are only
+// ever added to this table.
+type GormScoreChange struct {
+	gorm.Model
+	// QuizID is the quiz of the answer whose points changed.
+	QuizID uint
+	// GormQuestionID is the question of the answer whose points changed.
+	GormQuestionID uint
+	// GormAnswerID is the answer whose points changed.
+	GormAnswerID uint
+	// SolverID is the participant who gave the answer.
+	SolverID uint
+	// ProtoData contains the serialized ScoreChange proto
+	ProtoData []byte
+}
+
+// getUserDisplayName is how the user is named in the score change log.
+func getUserDisplayName(u *User) string {
+	if name := u.GetGoogleUser().GetName(); name != "" {
+		return name
+	}
+	return fmt.Sprintf("Guest %v", u.GetId())
+}
+
+// recordScoreChange adds a row to the score change log if the points of the answer differ
+// from oldPoints, which is nil if the answer had not been graded. A first grade is a change
+// even when it is 0 points. changedBy is nil when the answer was graded automatically.
+func recordScoreChange(tx *gorm.DB, qzid int64, ans *Answer, oldPoints *int64, changedBy *User) error {
+	if oldPoints == nil && ans.PointsAwarded == nil ||
+		oldPoints != nil && ans.PointsAwarded != nil && *oldPoints == ans.GetPointsAwarded() {
+		return nil
+	}
+	sc := &ScoreChange{
+		AnswerId:    proto.Int64(ans.GetId()),
+		QuestionId:  proto.Int64(ans.GetQuestionId()),
+		QuizId:      proto.Int64(qzid),
+		SolverId:    proto.Int64(ans.GetSolverId()),
+		OldPoints:   oldPoints,
+		NewPoints:   proto.Int64(ans.GetPointsAwarded()),
+		ChangedAtMs: proto.Int64(time.Now().UnixMilli()),
+	}
+	if changedBy != nil {
+		sc.ChangedBy = proto.Int64(changedBy.GetId())
+		sc.ChangedByName = proto.String(getUserDisplayName(changedBy))
+	}
+	b, err := proto.Marshal(sc)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&GormScoreChange{
+		QuizID:         uint(qzid),
+		GormQuestionID: uint(ans.GetQuestionId()),
+		GormAnswerID:   uint(ans.GetId()),
+		SolverID:       uint(ans.GetSolverId()),
+		ProtoData:      b,
+	}).Error
+}
+
+// GetScoreChangesForQuestion fetches the changes to the points of all answers to the question,
+// oldest first.
+func (p *Persistence) GetScoreChangesForQuestion(qnid int64) ([]*ScoreChange, error) {
+	return p.getScoreChanges("gorm_question_id = ?", uint(qnid))
+}
+
+// GetScoreChangesForParticipant fetches the changes to the points of all answers the participant
+// gave in the quiz, oldest first.
+func (p *Persistence) GetScoreChangesForParticipant(qzid int64, userID int64) ([]*ScoreChange, error) {
+	return p.getScoreChanges("quiz_id = ? AND solver_id = ?", uint(qzid), uint(userID))
+}
+
+func (p *Persistence) getScoreChanges(query string, args ...interface{}) ([]*ScoreChange, error) {
+	var gscs []GormScoreChange
+	if err := p.db.Where(query, args...).Order("id").Find(&gscs).Error; err != nil {
+		return nil, err
+	}
+	scs := make([]*ScoreChange, 0, len(gscs))
+	for _, gsc := range gscs {
+		var sc ScoreChange
+		if err := proto.Unmarshal(gsc.ProtoData, &sc); err != nil {
+			return nil, err
+		}
+		sc.Id = proto.Int64(int64(gsc.ID))
+		scs = append(scs, &sc)
+	}
+	return scs, nil
+}
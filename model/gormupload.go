@@ -0,0 +1,67 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"google.golang.org/protobuf/proto"
+	"gorm.io/gorm"
+)
+
+// GormUpload is the persisted version of the Upload proto
+type GormUpload struct {
+	gorm.Model
+	// QuizID is the quiz the file was uploaded for.
+	QuizID uint
+	// GormUserID is the user who uploaded the file.
+	GormUserID uint
+	// ProtoData contains the serialized Upload proto, including the file.
+	ProtoData []byte
+}
+
+// CreateUpload stores a new uploaded file in the db, and returns its ID.
+func (p *Persistence) CreateUpload(up *Upload) (uint, error) {
+	var gu GormUpload
+	gu.QuizID = uint(up.GetQuizId())
+	gu.GormUserID = uint(up.GetUploaderId())
+	b, err := proto.Marshal(up)
+	if err != nil {
+		return 0, err
+	}
+	gu.ProtoData = b
+	if err := p.db.Create(&gu).Error; err != nil {
+		return 0, err
+	}
+	return gu.ID, nil
+}
+
+// GetUploadByID fetches an uploaded file by its ID.
+func (p *Persistence) GetUploadByID(id uint) (*Upload, error) {
+	var gu GormUpload
+	if err := p.db.First(&gu, id).Error; err != nil {
+		return nil, err
+	}
+	var up Upload
+	if err := proto.Unmarshal(gu.ProtoData, &up); err != nil {
+		return nil, err
+	}
+	up.Id = proto.Int64(int64(gu.ID))
+	return &up, nil
+}
+
+// DeleteUpload removes an uploaded file from the db. Unlike other deletes, it does not
+// keep the row, so that the space taken by the file is freed.
+func (p *Persistence) DeleteUpload(id uint) error {
+	return p.db.Unscoped().Delete(&GormUpload{}, id).Error
+}
@@ -0,0 +1,53 @@
+// Copyright 2020 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"bytes"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+)
+
+func TestUploadBasicOperations(t *testing.T) {
+	var p Persistence
+	if err := p.Initialize(":memory:", "oauth_client_fake_id"); err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("\x89PNG\r\n\x1a\nrest of the image")
+	id, err := p.CreateUpload(&Upload{QuizId: proto.Int64(3), UploaderId: proto.Int64(7),
+		MimeType: proto.String("image/png"), Data: data})
+	if err != nil {
+		t.Fatalf("could not create upload. %v", err)
+	}
+	up, err := p.GetUploadByID(id)
+	if err != nil {
+		t.Fatalf("could not fetch upload. %v", err)
+	}
+	if up.GetId() != int64(id) || up.GetQuizId() != 3 || up.GetUploaderId() != 7 ||
+		up.GetMimeType() != "image/png" || !bytes.Equal(up.GetData(), data) {
+		t.Errorf("got back a different upload: %v", up)
+	}
+	if _, err := p.GetUploadByID(id + 1); err == nil {
+		t.Errorf("want an error for an upload that does not exist")
+	}
+	if err := p.DeleteUpload(id); err != nil {
+		t.Fatalf("could not delete upload. %v", err)
+	}
+	if _, err := p.GetUploadByID(id); err == nil {
+		t.Errorf("want an error for a deleted upload")
+	}
+}
@@ -28,6 +28,8 @@ type Persistence struct {
 	db *gorm.DB
 	// OAuthClientID is the id from the Google Developers API Console that identifies this application
 	OAuthClientID string
+	// Events tells subscribers about changes to quizzes once they are committed.
+	Events EventBus
 
 	certMu sync.Mutex
 }
@@ -51,7 +53,10 @@ func (p *Persistence) Initialize(dbPath string, oci string) error {
 		&GormUser{},
 		&GormCookie{},
 		&GormAccessControl{},
-		&GormCert{}); err != nil {
+		&GormCert{},
+		&GormUpload{},
+		&GormScoreChange{},
+		&GormBankQuestion{}); err != nil {
 		return err
 	}
 	return nil
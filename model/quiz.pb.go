@@ -39,6 +39,64 @@ const (
 // of the legacy proto package is being used.
 const _ = proto.ProtoPackageIsVersion4
 
+type SpeedBonusFormula int32
+
+const (
+	SpeedBonusFormula_NO_SPEED_BONUS SpeedBonusFormula = 0
+	// The bonus drops in a straight line from max_points when the question is
+	// activated to nothing at the end of the window.
+	SpeedBonusFormula_LINEAR_DECAY SpeedBonusFormula = 1
+)
+
+// Enum value maps for SpeedBonusFormula.
+var (
+	SpeedBonusFormula_name = map[int32]string{
+		0: "NO_SPEED_BONUS",
+		1: "LINEAR_DECAY",
+	}
+	SpeedBonusFormula_value = map[string]int32{
+		"NO_SPEED_BONUS": 0,
+		"LINEAR_DECAY":   1,
+	}
+)
+
+func (x SpeedBonusFormula) Enum() *SpeedBonusFormula {
+	p := new(SpeedBonusFormula)
+	*p = x
+	return p
+}
+
+func (x SpeedBonusFormula) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SpeedBonusFormula) Descriptor() protoreflect.EnumDescriptor {
+	return file_quiz_proto_enumTypes[0].Descriptor()
+}
+
+func (SpeedBonusFormula) Type() protoreflect.EnumType {
+	return &file_quiz_proto_enumTypes[0]
+}
+
+func (x SpeedBonusFormula) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *SpeedBonusFormula) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = SpeedBonusFormula(num)
+	return nil
+}
+
+// Deprecated: Use SpeedBonusFormula.Descriptor instead.
+func (SpeedBonusFormula) EnumDescriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{0}
+}
+
 type QuizState int32
 
 const (
@@ -78,11 +136,11 @@ func (x QuizState) String() string {
 }
 
 func (QuizState) Descriptor() protoreflect.EnumDescriptor {
-	return file_quiz_proto_enumTypes[0].Descriptor()
+	return file_quiz_proto_enumTypes[1].Descriptor()
 }
 
 func (QuizState) Type() protoreflect.EnumType {
-	return &file_quiz_proto_enumTypes[0]
+	return &file_quiz_proto_enumTypes[1]
 }
 
 func (x QuizState) Number() protoreflect.EnumNumber {
@@ -101,7 +159,198 @@ func (x *QuizState) UnmarshalJSON(b []byte) error {
 
 // Deprecated: Use QuizState.Descriptor instead.
 func (QuizState) EnumDescriptor() ([]byte, []int) {
-	return file_quiz_proto_rawDescGZIP(), []int{0}
+	return file_quiz_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderingGrading int32
+
+const (
+	// Only exactly the correct order gets any points.
+	OrderingGrading_EXACT_ORDER OrderingGrading = 0
+	// Each item in its correct position gets an equal share of the points.
+	OrderingGrading_POSITIONS_CORRECT OrderingGrading = 1
+	// The points go down with the number of pairs of items that are the wrong way
+	// round (the Kendall tau distance), down to nothing for the reverse order.
+	OrderingGrading_KENDALL_TAU OrderingGrading = 2
+)
+
+// Enum value maps for OrderingGrading.
+var (
+	OrderingGrading_name = map[int32]string{
+		0: "EXACT_ORDER",
+		1: "POSITIONS_CORRECT",
+		2: "KENDALL_TAU",
+	}
+	OrderingGrading_value = map[string]int32{
+		"EXACT_ORDER":       0,
+		"POSITIONS_CORRECT": 1,
+		"KENDALL_TAU":       2,
+	}
+)
+
+func (x OrderingGrading) Enum() *OrderingGrading {
+	p := new(OrderingGrading)
+	*p = x
+	return p
+}
+
+func (x OrderingGrading) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderingGrading) Descriptor() protoreflect.EnumDescriptor {
+	return file_quiz_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderingGrading) Type() protoreflect.EnumType {
+	return &file_quiz_proto_enumTypes[2]
+}
+
+func (x OrderingGrading) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *OrderingGrading) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = OrderingGrading(num)
+	return nil
+}
+
+// Deprecated: Use OrderingGrading.Descriptor instead.
+func (OrderingGrading) EnumDescriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{2}
+}
+
+type MultiSelectGrading int32
+
+const (
+	// Only exactly the correct options get any points.
+	MultiSelectGrading_ALL_OR_NOTHING MultiSelectGrading = 0
+	// Each correct option chosen gets an equal share of the points. Wrong options
+	// are ignored.
+	MultiSelectGrading_PER_CORRECT_OPTION MultiSelectGrading = 1
+	// As PER_CORRECT_OPTION, but each wrong option chosen takes away a share too.
+	MultiSelectGrading_PENALTY_PER_WRONG_OPTION MultiSelectGrading = 2
+)
+
+// Enum value maps for MultiSelectGrading.
+var (
+	MultiSelectGrading_name = map[int32]string{
+		0: "ALL_OR_NOTHING",
+		1: "PER_CORRECT_OPTION",
+		2: "PENALTY_PER_WRONG_OPTION",
+	}
+	MultiSelectGrading_value = map[string]int32{
+		"ALL_OR_NOTHING":           0,
+		"PER_CORRECT_OPTION":       1,
+		"PENALTY_PER_WRONG_OPTION": 2,
+	}
+)
+
+func (x MultiSelectGrading) Enum() *MultiSelectGrading {
+	p := new(MultiSelectGrading)
+	*p = x
+	return p
+}
+
+func (x MultiSelectGrading) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MultiSelectGrading) Descriptor() protoreflect.EnumDescriptor {
+	return file_quiz_proto_enumTypes[3].Descriptor()
+}
+
+func (MultiSelectGrading) Type() protoreflect.EnumType {
+	return &file_quiz_proto_enumTypes[3]
+}
+
+func (x MultiSelectGrading) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *MultiSelectGrading) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = MultiSelectGrading(num)
+	return nil
+}
+
+// Deprecated: Use MultiSelectGrading.Descriptor instead.
+func (MultiSelectGrading) EnumDescriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{3}
+}
+
+// TextMatch is how closely a text answer matched the answer key. For CLOZE_ANSWER, it is
+// FUZZY_MATCH if any blank only matched fuzzily, and otherwise EXACT_MATCH or NO_MATCH
+// only if every blank did.
+type TextMatch int32
+
+const (
+	TextMatch_UNKNOWN_TEXT_MATCH TextMatch = 0
+	TextMatch_EXACT_MATCH        TextMatch = 1
+	TextMatch_FUZZY_MATCH        TextMatch = 2
+	TextMatch_NO_MATCH           TextMatch = 3
+)
+
+// Enum value maps for TextMatch.
+var (
+	TextMatch_name = map[int32]string{
+		0: "UNKNOWN_TEXT_MATCH",
+		1: "EXACT_MATCH",
+		2: "FUZZY_MATCH",
+		3: "NO_MATCH",
+	}
+	TextMatch_value = map[string]int32{
+		"UNKNOWN_TEXT_MATCH": 0,
+		"EXACT_MATCH":        1,
+		"FUZZY_MATCH":        2,
+		"NO_MATCH":           3,
+	}
+)
+
+func (x TextMatch) Enum() *TextMatch {
+	p := new(TextMatch)
+	*p = x
+	return p
+}
+
+func (x TextMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TextMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_quiz_proto_enumTypes[4].Descriptor()
+}
+
+func (TextMatch) Type() protoreflect.EnumType {
+	return &file_quiz_proto_enumTypes[4]
+}
+
+func (x TextMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *TextMatch) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = TextMatch(num)
+	return nil
+}
+
+// Deprecated: Use TextMatch.Descriptor instead.
+func (TextMatch) EnumDescriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{4}
 }
 
 type AnswerType int32
@@ -113,20 +362,34 @@ const (
 	AnswerType_FLOAT_ANSWER           AnswerType = 3
 	AnswerType_BOOL_ANSWER            AnswerType = 4
 	AnswerType_MULTIPLE_CHOICE_ANSWER AnswerType = 5
-	// This is just a display attribute; the data will be stored in ans_text
-	AnswerType_LONG_TEXT_ANSWER AnswerType = 6
+	AnswerType_LONG_TEXT_ANSWER       AnswerType = 6
+	// Any number of the choices can be picked.
+	AnswerType_MULTI_SELECT_ANSWER AnswerType = 7
+	// The choices are put in order.
+	AnswerType_ORDERING_ANSWER AnswerType = 8
+	// The choices are matched to the match_choices.
+	AnswerType_MATCHING_ANSWER AnswerType = 9
+	// The blanks in the body of the question are filled in.
+	AnswerType_CLOZE_ANSWER AnswerType = 10
+	// A file, such as a drawing or a photo, is uploaded.
+	AnswerType_UPLOAD_ANSWER AnswerType = 11
 )
 
 // Enum value maps for AnswerType.
 var (
 	AnswerType_name = map[int32]string{
-		0: "UNKNOWN_ANSWER_TYPE",
-		1: "TEXT_ANSWER",
-		2: "INT64_ANSWER",
-		3: "FLOAT_ANSWER",
-		4: "BOOL_ANSWER",
-		5: "MULTIPLE_CHOICE_ANSWER",
-		6: "LONG_TEXT_ANSWER",
+		0:  "UNKNOWN_ANSWER_TYPE",
+		1:  "TEXT_ANSWER",
+		2:  "INT64_ANSWER",
+		3:  "FLOAT_ANSWER",
+		4:  "BOOL_ANSWER",
+		5:  "MULTIPLE_CHOICE_ANSWER",
+		6:  "LONG_TEXT_ANSWER",
+		7:  "MULTI_SELECT_ANSWER",
+		8:  "ORDERING_ANSWER",
+		9:  "MATCHING_ANSWER",
+		10: "CLOZE_ANSWER",
+		11: "UPLOAD_ANSWER",
 	}
 	AnswerType_value = map[string]int32{
 		"UNKNOWN_ANSWER_TYPE":    0,
@@ -136,6 +399,11 @@ var (
 		"BOOL_ANSWER":            4,
 		"MULTIPLE_CHOICE_ANSWER": 5,
 		"LONG_TEXT_ANSWER":       6,
+		"MULTI_SELECT_ANSWER":    7,
+		"ORDERING_ANSWER":        8,
+		"MATCHING_ANSWER":        9,
+		"CLOZE_ANSWER":           10,
+		"UPLOAD_ANSWER":          11,
 	}
 )
 
@@ -150,11 +418,11 @@ func (x AnswerType) String() string {
 }
 
 func (AnswerType) Descriptor() protoreflect.EnumDescriptor {
-	return file_quiz_proto_enumTypes[1].Descriptor()
+	return file_quiz_proto_enumTypes[5].Descriptor()
 }
 
 func (AnswerType) Type() protoreflect.EnumType {
-	return &file_quiz_proto_enumTypes[1]
+	return &file_quiz_proto_enumTypes[5]
 }
 
 func (x AnswerType) Number() protoreflect.EnumNumber {
@@ -173,7 +441,7 @@ func (x *AnswerType) UnmarshalJSON(b []byte) error {
 
 // Deprecated: Use AnswerType.Descriptor instead.
 func (AnswerType) EnumDescriptor() ([]byte, []int) {
-	return file_quiz_proto_rawDescGZIP(), []int{1}
+	return file_quiz_proto_rawDescGZIP(), []int{5}
 }
 
 // A Quiz represents a single game with many questions, quizmasters, and participants.
@@ -184,7 +452,8 @@ type Quiz struct {
 
 	Id    *int64     `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 	State *QuizState `protobuf:"varint,2,opt,name=state,enum=model.QuizState" json:"state,omitempty"`
-	// What time we expect the quiz to start.
+	// What time we expect the quiz to start, in seconds since the Unix epoch.
+	// Participants who arrive before then wait in the lobby.
 	ExpectedStartTime *int64 `protobuf:"varint,3,opt,name=expected_start_time,json=expectedStartTime" json:"expected_start_time,omitempty"`
 	// What time the quiz actually started (the time of the first answer submission).
 	ActualStartTime  *int64                `protobuf:"varint,4,opt,name=actual_start_time,json=actualStartTime" json:"actual_start_time,omitempty"`
@@ -198,6 +467,32 @@ type Quiz struct {
 	LiveQuestionId *int64 `protobuf:"varint,10,opt,name=live_question_id,json=liveQuestionId" json:"live_question_id,omitempty"`
 	// During a live quiz, indicates if the live question is currently accepting responses.
 	AcceptingResponses *bool `protobuf:"varint,12,opt,name=accepting_responses,json=acceptingResponses" json:"accepting_responses,omitempty"`
+	// During a live quiz, the time at which the live question stops accepting responses,
+	// in milliseconds since the Unix epoch. Not set if the question has no time limit.
+	ResponseDeadlineMs *int64 `protobuf:"varint,13,opt,name=response_deadline_ms,json=responseDeadlineMs" json:"response_deadline_ms,omitempty"`
+	// If set, the first question is activated automatically at the expected_start_time.
+	AutoStart *bool `protobuf:"varint,14,opt,name=auto_start,json=autoStart" json:"auto_start,omitempty"`
+	// During a live quiz, indicates if the solution to the live question is being shown to the
+	// participants. Responses are not accepted while the solution is shown.
+	AnswerRevealed *bool `protobuf:"varint,15,opt,name=answer_revealed,json=answerRevealed" json:"answer_revealed,omitempty"`
+	// When each question was last activated, in milliseconds since the Unix epoch,
+	// keyed by question ID.
+	QuestionActivatedMs map[int64]int64 `protobuf:"bytes,16,rep,name=question_activated_ms,json=questionActivatedMs" json:"question_activated_ms,omitempty" protobuf_key:"varint,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	// Extra points for answering quickly. Not set for no bonus.
+	SpeedBonus *SpeedBonus `protobuf:"bytes,17,opt,name=speed_bonus,json=speedBonus" json:"speed_bonus,omitempty"`
+	// The points the quizmaster can give for answers, unless a question says otherwise.
+	PointScale *PointScale `protobuf:"bytes,18,opt,name=point_scale,json=pointScale" json:"point_scale,omitempty"`
+	// If set, each participant can play a joker on one round before its first question is
+	// activated, to double their points in it.
+	JokerEnabled *bool `protobuf:"varint,19,opt,name=joker_enabled,json=jokerEnabled" json:"joker_enabled,omitempty"`
+	// The sections of the quiz, such as themed sets of questions, in the order they are played.
+	Rounds []*Round `protobuf:"bytes,20,rep,name=rounds" json:"rounds,omitempty"`
+	// During a live quiz, the round whose title card is being shown. Not set while a question
+	// is shown. Responses are not accepted while the title card is shown.
+	LiveRoundId *int64 `protobuf:"varint,21,opt,name=live_round_id,json=liveRoundId" json:"live_round_id,omitempty"`
+	// The ID of the last round added. Round IDs are not handed out again, so that the
+	// questions of a deleted round do not join a new one.
+	LastRoundId *int64 `protobuf:"varint,22,opt,name=last_round_id,json=lastRoundId" json:"last_round_id,omitempty"`
 }
 
 func (x *Quiz) Reset() {
@@ -316,27 +611,94 @@ func (x *Quiz) GetAcceptingResponses() bool {
 	return false
 }
 
-// Quizmasters for a quiz identified by various IDs.
-// At least one ID must be set. Equality checking will be done by
-// the priority 1 field if set, otherwise will move on to the next priority.
-type QuizmasterProfile struct {
+func (x *Quiz) GetResponseDeadlineMs() int64 {
+	if x != nil && x.ResponseDeadlineMs != nil {
+		return *x.ResponseDeadlineMs
+	}
+	return 0
+}
+
+func (x *Quiz) GetAutoStart() bool {
+	if x != nil && x.AutoStart != nil {
+		return *x.AutoStart
+	}
+	return false
+}
+
+func (x *Quiz) GetAnswerRevealed() bool {
+	if x != nil && x.AnswerRevealed != nil {
+		return *x.AnswerRevealed
+	}
+	return false
+}
+
+func (x *Quiz) GetQuestionActivatedMs() map[int64]int64 {
+	if x != nil {
+		return x.QuestionActivatedMs
+	}
+	return nil
+}
+
+func (x *Quiz) GetSpeedBonus() *SpeedBonus {
+	if x != nil {
+		return x.SpeedBonus
+	}
+	return nil
+}
+
+func (x *Quiz) GetPointScale() *PointScale {
+	if x != nil {
+		return x.PointScale
+	}
+	return nil
+}
+
+func (x *Quiz) GetJokerEnabled() bool {
+	if x != nil && x.JokerEnabled != nil {
+		return *x.JokerEnabled
+	}
+	return false
+}
+
+func (x *Quiz) GetRounds() []*Round {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Quiz) GetLiveRoundId() int64 {
+	if x != nil && x.LiveRoundId != nil {
+		return *x.LiveRoundId
+	}
+	return 0
+}
+
+func (x *Quiz) GetLastRoundId() int64 {
+	if x != nil && x.LastRoundId != nil {
+		return *x.LastRoundId
+	}
+	return 0
+}
+
+// A Round is a named section of the quiz. Questions join a round through their round_id.
+type Round struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	// The user ID as stored in the local database's primary key.
-	// This will be set for the creator and guest users. Priority 2.
-	UserId *int64 `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
-	// The google sub as set in the claim from OAuth. Set for
-	// creators who logged in via Google. Priority 1.
-	GoogleSub *string `protobuf:"bytes,2,opt,name=google_sub,json=googleSub" json:"google_sub,omitempty"`
-	// The emial address as set in the claim from OAuth. Primarily
-	// set when the QM invites other people to edit. Priority 3.
-	GoogleEmail *string `protobuf:"bytes,3,opt,name=google_email,json=googleEmail" json:"google_email,omitempty"`
+	// Unique within the quiz.
+	Id    *int64  `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	Title *string `protobuf:"bytes,2,opt,name=title" json:"title,omitempty"`
+	// Shown on the title card of the round.
+	HtmlIntro *string `protobuf:"bytes,3,opt,name=html_intro,json=htmlIntro" json:"html_intro,omitempty"`
+	// The points the quizmaster can give for answers in this round, unless a question
+	// says otherwise. If not set, the point scale of the quiz is used.
+	PointScale *PointScale `protobuf:"bytes,4,opt,name=point_scale,json=pointScale" json:"point_scale,omitempty"`
 }
 
-func (x *QuizmasterProfile) Reset() {
-	*x = QuizmasterProfile{}
+func (x *Round) Reset() {
+	*x = Round{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_quiz_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -344,13 +706,13 @@ func (x *QuizmasterProfile) Reset() {
 	}
 }
 
-func (x *QuizmasterProfile) String() string {
+func (x *Round) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QuizmasterProfile) ProtoMessage() {}
+func (*Round) ProtoMessage() {}
 
-func (x *QuizmasterProfile) ProtoReflect() protoreflect.Message {
+func (x *Round) ProtoReflect() protoreflect.Message {
 	mi := &file_quiz_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -362,44 +724,54 @@ func (x *QuizmasterProfile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QuizmasterProfile.ProtoReflect.Descriptor instead.
-func (*QuizmasterProfile) Descriptor() ([]byte, []int) {
+// Deprecated: Use Round.ProtoReflect.Descriptor instead.
+func (*Round) Descriptor() ([]byte, []int) {
 	return file_quiz_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *QuizmasterProfile) GetUserId() int64 {
-	if x != nil && x.UserId != nil {
-		return *x.UserId
+func (x *Round) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
 	}
 	return 0
 }
 
-func (x *QuizmasterProfile) GetGoogleSub() string {
-	if x != nil && x.GoogleSub != nil {
-		return *x.GoogleSub
+func (x *Round) GetTitle() string {
+	if x != nil && x.Title != nil {
+		return *x.Title
 	}
 	return ""
 }
 
-func (x *QuizmasterProfile) GetGoogleEmail() string {
-	if x != nil && x.GoogleEmail != nil {
-		return *x.GoogleEmail
+func (x *Round) GetHtmlIntro() string {
+	if x != nil && x.HtmlIntro != nil {
+		return *x.HtmlIntro
 	}
 	return ""
 }
 
-type ParticipantProfile struct {
+func (x *Round) GetPointScale() *PointScale {
+	if x != nil {
+		return x.PointScale
+	}
+	return nil
+}
+
+// SpeedBonus gives extra points to correct answers, on top of the points for the question.
+type SpeedBonus struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	UserId                *int64  `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
-	ProfileName           *string `protobuf:"bytes,2,opt,name=profile_name,json=profileName" json:"profile_name,omitempty"`
-	CompletedRegistration *bool   `protobuf:"varint,3,opt,name=completed_registration,json=completedRegistration" json:"completed_registration,omitempty"`
+	Formula   *SpeedBonusFormula `protobuf:"varint,1,opt,name=formula,enum=model.SpeedBonusFormula" json:"formula,omitempty"`
+	MaxPoints *int64             `protobuf:"varint,2,opt,name=max_points,json=maxPoints" json:"max_points,omitempty"`
+	// How long after the question is activated there is a bonus to be had. If not set,
+	// the time limit of the question is used, and questions without one get no bonus.
+	WindowS *int64 `protobuf:"varint,3,opt,name=window_s,json=windowS" json:"window_s,omitempty"`
 }
 
-func (x *ParticipantProfile) Reset() {
-	*x = ParticipantProfile{}
+func (x *SpeedBonus) Reset() {
+	*x = SpeedBonus{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_quiz_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -407,13 +779,13 @@ func (x *ParticipantProfile) Reset() {
 	}
 }
 
-func (x *ParticipantProfile) String() string {
+func (x *SpeedBonus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ParticipantProfile) ProtoMessage() {}
+func (*SpeedBonus) ProtoMessage() {}
 
-func (x *ParticipantProfile) ProtoReflect() protoreflect.Message {
+func (x *SpeedBonus) ProtoReflect() protoreflect.Message {
 	mi := &file_quiz_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -425,53 +797,227 @@ func (x *ParticipantProfile) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ParticipantProfile.ProtoReflect.Descriptor instead.
-func (*ParticipantProfile) Descriptor() ([]byte, []int) {
+// Deprecated: Use SpeedBonus.ProtoReflect.Descriptor instead.
+func (*SpeedBonus) Descriptor() ([]byte, []int) {
 	return file_quiz_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *ParticipantProfile) GetUserId() int64 {
-	if x != nil && x.UserId != nil {
-		return *x.UserId
+func (x *SpeedBonus) GetFormula() SpeedBonusFormula {
+	if x != nil && x.Formula != nil {
+		return *x.Formula
 	}
-	return 0
+	return SpeedBonusFormula_NO_SPEED_BONUS
 }
 
-func (x *ParticipantProfile) GetProfileName() string {
-	if x != nil && x.ProfileName != nil {
-		return *x.ProfileName
+func (x *SpeedBonus) GetMaxPoints() int64 {
+	if x != nil && x.MaxPoints != nil {
+		return *x.MaxPoints
 	}
-	return ""
+	return 0
 }
 
-func (x *ParticipantProfile) GetCompletedRegistration() bool {
-	if x != nil && x.CompletedRegistration != nil {
-		return *x.CompletedRegistration
+func (x *SpeedBonus) GetWindowS() int64 {
+	if x != nil && x.WindowS != nil {
+		return *x.WindowS
 	}
-	return false
+	return 0
 }
 
-type Question struct {
+// Quizmasters for a quiz identified by various IDs.
+// At least one ID must be set. Equality checking will be done by
+// the priority 1 field if set, otherwise will move on to the next priority.
+type QuizmasterProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The user ID as stored in the local database's primary key.
+	// This will be set for the creator and guest users. Priority 2.
+	UserId *int64 `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	// The google sub as set in the claim from OAuth. Set for
+	// creators who logged in via Google. Priority 1.
+	GoogleSub *string `protobuf:"bytes,2,opt,name=google_sub,json=googleSub" json:"google_sub,omitempty"`
+	// The emial address as set in the claim from OAuth. Primarily
+	// set when the QM invites other people to edit. Priority 3.
+	GoogleEmail *string `protobuf:"bytes,3,opt,name=google_email,json=googleEmail" json:"google_email,omitempty"`
+}
+
+func (x *QuizmasterProfile) Reset() {
+	*x = QuizmasterProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuizmasterProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuizmasterProfile) ProtoMessage() {}
+
+func (x *QuizmasterProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuizmasterProfile.ProtoReflect.Descriptor instead.
+func (*QuizmasterProfile) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QuizmasterProfile) GetUserId() int64 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *QuizmasterProfile) GetGoogleSub() string {
+	if x != nil && x.GoogleSub != nil {
+		return *x.GoogleSub
+	}
+	return ""
+}
+
+func (x *QuizmasterProfile) GetGoogleEmail() string {
+	if x != nil && x.GoogleEmail != nil {
+		return *x.GoogleEmail
+	}
+	return ""
+}
+
+type ParticipantProfile struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId                *int64  `protobuf:"varint,1,opt,name=user_id,json=userId" json:"user_id,omitempty"`
+	ProfileName           *string `protobuf:"bytes,2,opt,name=profile_name,json=profileName" json:"profile_name,omitempty"`
+	CompletedRegistration *bool   `protobuf:"varint,3,opt,name=completed_registration,json=completedRegistration" json:"completed_registration,omitempty"`
+	// The round the participant played their joker on, if any.
+	JokerRoundId *int64 `protobuf:"varint,4,opt,name=joker_round_id,json=jokerRoundId" json:"joker_round_id,omitempty"`
+}
+
+func (x *ParticipantProfile) Reset() {
+	*x = ParticipantProfile{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ParticipantProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParticipantProfile) ProtoMessage() {}
+
+func (x *ParticipantProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParticipantProfile.ProtoReflect.Descriptor instead.
+func (*ParticipantProfile) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ParticipantProfile) GetUserId() int64 {
+	if x != nil && x.UserId != nil {
+		return *x.UserId
+	}
+	return 0
+}
+
+func (x *ParticipantProfile) GetProfileName() string {
+	if x != nil && x.ProfileName != nil {
+		return *x.ProfileName
+	}
+	return ""
+}
+
+func (x *ParticipantProfile) GetCompletedRegistration() bool {
+	if x != nil && x.CompletedRegistration != nil {
+		return *x.CompletedRegistration
+	}
+	return false
+}
+
+func (x *ParticipantProfile) GetJokerRoundId() int64 {
+	if x != nil && x.JokerRoundId != nil {
+		return *x.JokerRoundId
+	}
+	return 0
+}
+
+type Question struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
 	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
 	// Which quiz this question is a part of.
-	QuizId   *int64      `protobuf:"varint,2,opt,name=quiz_id,json=quizId" json:"quiz_id,omitempty"`
-	Title    *string     `protobuf:"bytes,3,opt,name=title" json:"title,omitempty"`
+	QuizId *int64  `protobuf:"varint,2,opt,name=quiz_id,json=quizId" json:"quiz_id,omitempty"`
+	Title  *string `protobuf:"bytes,3,opt,name=title" json:"title,omitempty"`
+	// For CLOZE_ANSWER, each run of three or more underscores is a blank.
 	HtmlBody *string     `protobuf:"bytes,4,opt,name=html_body,json=htmlBody" json:"html_body,omitempty"`
 	Type     *AnswerType `protobuf:"varint,5,opt,name=type,enum=model.AnswerType" json:"type,omitempty"`
-	// Only valid if type = MULTIPLE_CHOICE_ANSWER
+	// Only valid if type = MULTIPLE_CHOICE_ANSWER or MULTI_SELECT_ANSWER. For ORDERING_ANSWER,
+	// the items to be put in order, as they are first shown to the participants. For
+	// MATCHING_ANSWER, the first column, whose items are matched to match_choices.
 	Choices []*AnswerChoice `protobuf:"bytes,6,rep,name=choices" json:"choices,omitempty"`
 	// Responses to this question by the participants
 	Answers []*Answer `protobuf:"bytes,7,rep,name=answers" json:"answers,omitempty"`
+	// If set, how long participants have to respond once the question is activated.
+	TimeLimitS *int64 `protobuf:"varint,8,opt,name=time_limit_s,json=timeLimitS" json:"time_limit_s,omitempty"`
+	// The solution that is shown to the participants when the answer is revealed.
+	HtmlSolution *string `protobuf:"bytes,9,opt,name=html_solution,json=htmlSolution" json:"html_solution,omitempty"`
+	// If set, answers are graded automatically against this key.
+	AnswerKey *AnswerKey `protobuf:"bytes,10,opt,name=answer_key,json=answerKey" json:"answer_key,omitempty"`
+	// How many points a correct answer gets. If not set, it gets 10 points.
+	Points *int64 `protobuf:"varint,11,opt,name=points" json:"points,omitempty"`
+	// The points the quizmaster can give for answers to this question. If not set,
+	// the point scale of the quiz is used.
+	PointScale *PointScale `protobuf:"bytes,12,opt,name=point_scale,json=pointScale" json:"point_scale,omitempty"`
+	// For MATCHING_ANSWER, the second column.
+	MatchChoices []*AnswerChoice `protobuf:"bytes,13,rep,name=match_choices,json=matchChoices" json:"match_choices,omitempty"`
+	// If set, participants bet points on their answer instead of earning the points of the
+	// question. An answer that gets any points wins the wager, and any other answer loses it.
+	Wager *bool `protobuf:"varint,14,opt,name=wager" json:"wager,omitempty"`
+	// If set, the question does not score points. Ties on the scoreboard go to the answer
+	// closest to the answer key, and then to the earlier answer. Only for INT64_ANSWER
+	// and FLOAT_ANSWER.
+	TieBreaker *bool `protobuf:"varint,15,opt,name=tie_breaker,json=tieBreaker" json:"tie_breaker,omitempty"`
+	// The round that the question is part of, if any.
+	RoundId *int64 `protobuf:"varint,16,opt,name=round_id,json=roundId" json:"round_id,omitempty"`
+	// The question bank entry that this question was copied or linked from, or saved to.
+	BankQuestionId *int64 `protobuf:"varint,17,opt,name=bank_question_id,json=bankQuestionId" json:"bank_question_id,omitempty"`
+	// If set, changes to this question are also made to the bank question, and to every
+	// other question that is linked to it.
+	LinkedToBank *bool `protobuf:"varint,18,opt,name=linked_to_bank,json=linkedToBank" json:"linked_to_bank,omitempty"`
 }
 
 func (x *Question) Reset() {
 	*x = Question{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quiz_proto_msgTypes[3]
+		mi := &file_quiz_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -484,7 +1030,7 @@ func (x *Question) String() string {
 func (*Question) ProtoMessage() {}
 
 func (x *Question) ProtoReflect() protoreflect.Message {
-	mi := &file_quiz_proto_msgTypes[3]
+	mi := &file_quiz_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -497,7 +1043,7 @@ func (x *Question) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Question.ProtoReflect.Descriptor instead.
 func (*Question) Descriptor() ([]byte, []int) {
-	return file_quiz_proto_rawDescGZIP(), []int{3}
+	return file_quiz_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Question) GetId() int64 {
@@ -549,31 +1095,930 @@ func (x *Question) GetAnswers() []*Answer {
 	return nil
 }
 
-type AnswerChoice struct {
+func (x *Question) GetTimeLimitS() int64 {
+	if x != nil && x.TimeLimitS != nil {
+		return *x.TimeLimitS
+	}
+	return 0
+}
+
+func (x *Question) GetHtmlSolution() string {
+	if x != nil && x.HtmlSolution != nil {
+		return *x.HtmlSolution
+	}
+	return ""
+}
+
+func (x *Question) GetAnswerKey() *AnswerKey {
+	if x != nil {
+		return x.AnswerKey
+	}
+	return nil
+}
+
+func (x *Question) GetPoints() int64 {
+	if x != nil && x.Points != nil {
+		return *x.Points
+	}
+	return 0
+}
+
+func (x *Question) GetPointScale() *PointScale {
+	if x != nil {
+		return x.PointScale
+	}
+	return nil
+}
+
+func (x *Question) GetMatchChoices() []*AnswerChoice {
+	if x != nil {
+		return x.MatchChoices
+	}
+	return nil
+}
+
+func (x *Question) GetWager() bool {
+	if x != nil && x.Wager != nil {
+		return *x.Wager
+	}
+	return false
+}
+
+func (x *Question) GetTieBreaker() bool {
+	if x != nil && x.TieBreaker != nil {
+		return *x.TieBreaker
+	}
+	return false
+}
+
+func (x *Question) GetRoundId() int64 {
+	if x != nil && x.RoundId != nil {
+		return *x.RoundId
+	}
+	return 0
+}
+
+func (x *Question) GetBankQuestionId() int64 {
+	if x != nil && x.BankQuestionId != nil {
+		return *x.BankQuestionId
+	}
+	return 0
+}
+
+func (x *Question) GetLinkedToBank() bool {
+	if x != nil && x.LinkedToBank != nil {
+		return *x.LinkedToBank
+	}
+	return false
+}
+
+// PointScale is the points the quizmaster can give for an answer.
+type PointScale struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	HtmlBody *string `protobuf:"bytes,1,opt,name=html_body,json=htmlBody" json:"html_body,omitempty"`
+	// The scores offered as buttons when grading, in order. Other scores can still be
+	// entered by hand. If empty, the buttons are 0, 5 and 10.
+	Presets []int64 `protobuf:"varint,1,rep,name=presets" json:"presets,omitempty"`
+	// What an answer that does not match the answer key gets. It can be negative.
+	WrongAnswerPoints *int64 `protobuf:"varint,2,opt,name=wrong_answer_points,json=wrongAnswerPoints" json:"wrong_answer_points,omitempty"`
+	// The lowest and highest score the quizmaster can give, if set.
+	MinPoints *int64 `protobuf:"varint,3,opt,name=min_points,json=minPoints" json:"min_points,omitempty"`
+	MaxPoints *int64 `protobuf:"varint,4,opt,name=max_points,json=maxPoints" json:"max_points,omitempty"`
 }
 
-func (x *AnswerChoice) Reset() {
-	*x = AnswerChoice{}
+func (x *PointScale) Reset() {
+	*x = PointScale{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quiz_proto_msgTypes[4]
+		mi := &file_quiz_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *AnswerChoice) String() string {
+func (x *PointScale) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AnswerChoice) ProtoMessage() {}
+func (*PointScale) ProtoMessage() {}
 
-func (x *AnswerChoice) ProtoReflect() protoreflect.Message {
-	mi := &file_quiz_proto_msgTypes[4]
+func (x *PointScale) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointScale.ProtoReflect.Descriptor instead.
+func (*PointScale) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PointScale) GetPresets() []int64 {
+	if x != nil {
+		return x.Presets
+	}
+	return nil
+}
+
+func (x *PointScale) GetWrongAnswerPoints() int64 {
+	if x != nil && x.WrongAnswerPoints != nil {
+		return *x.WrongAnswerPoints
+	}
+	return 0
+}
+
+func (x *PointScale) GetMinPoints() int64 {
+	if x != nil && x.MinPoints != nil {
+		return *x.MinPoints
+	}
+	return 0
+}
+
+func (x *PointScale) GetMaxPoints() int64 {
+	if x != nil && x.MaxPoints != nil {
+		return *x.MaxPoints
+	}
+	return 0
+}
+
+// AnswerKey holds the correct answer to a question. Only the field that matches
+// the type of the question is used.
+type AnswerKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// For TEXT_ANSWER, any of these is correct. They are compared with the answer
+	// after the normalization below.
+	AcceptedTexts []string `protobuf:"bytes,1,rep,name=accepted_texts,json=acceptedTexts" json:"accepted_texts,omitempty"`
+	// For INT64_ANSWER
+	CorrectInt *int64 `protobuf:"varint,2,opt,name=correct_int,json=correctInt" json:"correct_int,omitempty"`
+	// For BOOL_ANSWER
+	CorrectBool *bool `protobuf:"varint,3,opt,name=correct_bool,json=correctBool" json:"correct_bool,omitempty"`
+	// For MULTIPLE_CHOICE_ANSWER, counting from 0.
+	CorrectChoiceIndex *int64 `protobuf:"varint,4,opt,name=correct_choice_index,json=correctChoiceIndex" json:"correct_choice_index,omitempty"`
+	// For TEXT_ANSWER, other names for the answer that are also correct. These must
+	// match after normalization, so that a short form like "NYC" does not accept "NYT".
+	Aliases []string `protobuf:"bytes,5,rep,name=aliases" json:"aliases,omitempty"`
+	// For TEXT_ANSWER, how answers are normalized before they are compared.
+	Normalization *TextNormalization `protobuf:"bytes,6,opt,name=normalization" json:"normalization,omitempty"`
+	// For TEXT_ANSWER, answers within this many single letter edits of an accepted
+	// text are correct too, but marked for the quizmaster to review. 0 turns it off.
+	MaxEditDistance *int64 `protobuf:"varint,7,opt,name=max_edit_distance,json=maxEditDistance" json:"max_edit_distance,omitempty"`
+	// For FLOAT_ANSWER
+	CorrectFloat *float64 `protobuf:"fixed64,8,opt,name=correct_float,json=correctFloat" json:"correct_float,omitempty"`
+	// For INT64_ANSWER and FLOAT_ANSWER, any answer from range_min to range_max is
+	// correct. When set, these are used instead of correct_int or correct_float.
+	RangeMin *float64 `protobuf:"fixed64,9,opt,name=range_min,json=rangeMin" json:"range_min,omitempty"`
+	RangeMax *float64 `protobuf:"fixed64,10,opt,name=range_max,json=rangeMax" json:"range_max,omitempty"`
+	// For INT64_ANSWER and FLOAT_ANSWER, answers close to the correct value get some
+	// of the points. An answer in more than one band gets the most points.
+	ToleranceBands []*ToleranceBand `protobuf:"bytes,11,rep,name=tolerance_bands,json=toleranceBands" json:"tolerance_bands,omitempty"`
+	// For MULTI_SELECT_ANSWER, the options that should be chosen, counting from 0.
+	CorrectChoiceIndices []int64 `protobuf:"varint,12,rep,name=correct_choice_indices,json=correctChoiceIndices" json:"correct_choice_indices,omitempty"`
+	// For MULTI_SELECT_ANSWER, how the points are worked out when only some of the
+	// chosen options are right.
+	MultiSelectGrading *MultiSelectGrading `protobuf:"varint,13,opt,name=multi_select_grading,json=multiSelectGrading,enum=model.MultiSelectGrading" json:"multi_select_grading,omitempty"`
+	// For ORDERING_ANSWER, the indices of the items in the correct order, counting from 0.
+	CorrectOrder []int64 `protobuf:"varint,14,rep,name=correct_order,json=correctOrder" json:"correct_order,omitempty"`
+	// For ORDERING_ANSWER, how the points are worked out when the order is partly right.
+	OrderingGrading *OrderingGrading `protobuf:"varint,15,opt,name=ordering_grading,json=orderingGrading,enum=model.OrderingGrading" json:"ordering_grading,omitempty"`
+	// For MATCHING_ANSWER, the correct pairs. Each correct pair gets an equal share
+	// of the points.
+	CorrectPairs []*MatchPair `protobuf:"bytes,16,rep,name=correct_pairs,json=correctPairs" json:"correct_pairs,omitempty"`
+	// For CLOZE_ANSWER, the key to each blank, in order. The answers to each blank are
+	// matched like TEXT_ANSWER, using the normalization and max_edit_distance above.
+	Blanks []*BlankKey `protobuf:"bytes,17,rep,name=blanks" json:"blanks,omitempty"`
+}
+
+func (x *AnswerKey) Reset() {
+	*x = AnswerKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnswerKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerKey) ProtoMessage() {}
+
+func (x *AnswerKey) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerKey.ProtoReflect.Descriptor instead.
+func (*AnswerKey) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AnswerKey) GetAcceptedTexts() []string {
+	if x != nil {
+		return x.AcceptedTexts
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetCorrectInt() int64 {
+	if x != nil && x.CorrectInt != nil {
+		return *x.CorrectInt
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetCorrectBool() bool {
+	if x != nil && x.CorrectBool != nil {
+		return *x.CorrectBool
+	}
+	return false
+}
+
+func (x *AnswerKey) GetCorrectChoiceIndex() int64 {
+	if x != nil && x.CorrectChoiceIndex != nil {
+		return *x.CorrectChoiceIndex
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetNormalization() *TextNormalization {
+	if x != nil {
+		return x.Normalization
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetMaxEditDistance() int64 {
+	if x != nil && x.MaxEditDistance != nil {
+		return *x.MaxEditDistance
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetCorrectFloat() float64 {
+	if x != nil && x.CorrectFloat != nil {
+		return *x.CorrectFloat
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetRangeMin() float64 {
+	if x != nil && x.RangeMin != nil {
+		return *x.RangeMin
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetRangeMax() float64 {
+	if x != nil && x.RangeMax != nil {
+		return *x.RangeMax
+	}
+	return 0
+}
+
+func (x *AnswerKey) GetToleranceBands() []*ToleranceBand {
+	if x != nil {
+		return x.ToleranceBands
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetCorrectChoiceIndices() []int64 {
+	if x != nil {
+		return x.CorrectChoiceIndices
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetMultiSelectGrading() MultiSelectGrading {
+	if x != nil && x.MultiSelectGrading != nil {
+		return *x.MultiSelectGrading
+	}
+	return MultiSelectGrading_ALL_OR_NOTHING
+}
+
+func (x *AnswerKey) GetCorrectOrder() []int64 {
+	if x != nil {
+		return x.CorrectOrder
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetOrderingGrading() OrderingGrading {
+	if x != nil && x.OrderingGrading != nil {
+		return *x.OrderingGrading
+	}
+	return OrderingGrading_EXACT_ORDER
+}
+
+func (x *AnswerKey) GetCorrectPairs() []*MatchPair {
+	if x != nil {
+		return x.CorrectPairs
+	}
+	return nil
+}
+
+func (x *AnswerKey) GetBlanks() []*BlankKey {
+	if x != nil {
+		return x.Blanks
+	}
+	return nil
+}
+
+// BlankKey holds the correct answers to one blank of a CLOZE_ANSWER question.
+type BlankKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AcceptedTexts []string `protobuf:"bytes,1,rep,name=accepted_texts,json=acceptedTexts" json:"accepted_texts,omitempty"`
+	// How many points the blank is worth. If not set, the points of the question are
+	// shared equally between the blanks.
+	Points *int64 `protobuf:"varint,2,opt,name=points" json:"points,omitempty"`
+}
+
+func (x *BlankKey) Reset() {
+	*x = BlankKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlankKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlankKey) ProtoMessage() {}
+
+func (x *BlankKey) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlankKey.ProtoReflect.Descriptor instead.
+func (*BlankKey) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BlankKey) GetAcceptedTexts() []string {
+	if x != nil {
+		return x.AcceptedTexts
+	}
+	return nil
+}
+
+func (x *BlankKey) GetPoints() int64 {
+	if x != nil && x.Points != nil {
+		return *x.Points
+	}
+	return 0
+}
+
+// MatchPair matches an item of the first column of a question to one of the second,
+// both counting from 0.
+type MatchPair struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Left  *int64 `protobuf:"varint,1,opt,name=left" json:"left,omitempty"`
+	Right *int64 `protobuf:"varint,2,opt,name=right" json:"right,omitempty"`
+}
+
+func (x *MatchPair) Reset() {
+	*x = MatchPair{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MatchPair) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchPair) ProtoMessage() {}
+
+func (x *MatchPair) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchPair.ProtoReflect.Descriptor instead.
+func (*MatchPair) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MatchPair) GetLeft() int64 {
+	if x != nil && x.Left != nil {
+		return *x.Left
+	}
+	return 0
+}
+
+func (x *MatchPair) GetRight() int64 {
+	if x != nil && x.Right != nil {
+		return *x.Right
+	}
+	return 0
+}
+
+// ToleranceBand is how far a numeric answer can be from the correct value, measured
+// from the nearest end of the range if a range is given.
+type ToleranceBand struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Answers at most this far away are in the band.
+	Absolute *float64 `protobuf:"fixed64,1,opt,name=absolute" json:"absolute,omitempty"`
+	// Answers at most this percentage of the correct value away are in the band.
+	RelativePercent *float64 `protobuf:"fixed64,2,opt,name=relative_percent,json=relativePercent" json:"relative_percent,omitempty"`
+	// Answers in the band get this percentage of the points.
+	PointsPercent *int64 `protobuf:"varint,3,opt,name=points_percent,json=pointsPercent,def=100" json:"points_percent,omitempty"`
+}
+
+// Default values for ToleranceBand fields.
+const (
+	Default_ToleranceBand_PointsPercent = int64(100)
+)
+
+func (x *ToleranceBand) Reset() {
+	*x = ToleranceBand{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ToleranceBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ToleranceBand) ProtoMessage() {}
+
+func (x *ToleranceBand) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ToleranceBand.ProtoReflect.Descriptor instead.
+func (*ToleranceBand) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ToleranceBand) GetAbsolute() float64 {
+	if x != nil && x.Absolute != nil {
+		return *x.Absolute
+	}
+	return 0
+}
+
+func (x *ToleranceBand) GetRelativePercent() float64 {
+	if x != nil && x.RelativePercent != nil {
+		return *x.RelativePercent
+	}
+	return 0
+}
+
+func (x *ToleranceBand) GetPointsPercent() int64 {
+	if x != nil && x.PointsPercent != nil {
+		return *x.PointsPercent
+	}
+	return Default_ToleranceBand_PointsPercent
+}
+
+// TextNormalization lists the differences that are ignored between text answers.
+type TextNormalization struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IgnoreCase        *bool `protobuf:"varint,1,opt,name=ignore_case,json=ignoreCase,def=1" json:"ignore_case,omitempty"`
+	IgnoreWhitespace  *bool `protobuf:"varint,2,opt,name=ignore_whitespace,json=ignoreWhitespace,def=1" json:"ignore_whitespace,omitempty"`
+	IgnorePunctuation *bool `protobuf:"varint,3,opt,name=ignore_punctuation,json=ignorePunctuation" json:"ignore_punctuation,omitempty"`
+	IgnoreDiacritics  *bool `protobuf:"varint,4,opt,name=ignore_diacritics,json=ignoreDiacritics" json:"ignore_diacritics,omitempty"`
+	// Ignores a leading "the", "a" or "an".
+	IgnoreArticles *bool `protobuf:"varint,5,opt,name=ignore_articles,json=ignoreArticles" json:"ignore_articles,omitempty"`
+}
+
+// Default values for TextNormalization fields.
+const (
+	Default_TextNormalization_IgnoreCase       = bool(true)
+	Default_TextNormalization_IgnoreWhitespace = bool(true)
+)
+
+func (x *TextNormalization) Reset() {
+	*x = TextNormalization{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextNormalization) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextNormalization) ProtoMessage() {}
+
+func (x *TextNormalization) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextNormalization.ProtoReflect.Descriptor instead.
+func (*TextNormalization) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *TextNormalization) GetIgnoreCase() bool {
+	if x != nil && x.IgnoreCase != nil {
+		return *x.IgnoreCase
+	}
+	return Default_TextNormalization_IgnoreCase
+}
+
+func (x *TextNormalization) GetIgnoreWhitespace() bool {
+	if x != nil && x.IgnoreWhitespace != nil {
+		return *x.IgnoreWhitespace
+	}
+	return Default_TextNormalization_IgnoreWhitespace
+}
+
+func (x *TextNormalization) GetIgnorePunctuation() bool {
+	if x != nil && x.IgnorePunctuation != nil {
+		return *x.IgnorePunctuation
+	}
+	return false
+}
+
+func (x *TextNormalization) GetIgnoreDiacritics() bool {
+	if x != nil && x.IgnoreDiacritics != nil {
+		return *x.IgnoreDiacritics
+	}
+	return false
+}
+
+func (x *TextNormalization) GetIgnoreArticles() bool {
+	if x != nil && x.IgnoreArticles != nil {
+		return *x.IgnoreArticles
+	}
+	return false
+}
+
+type AnswerChoice struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HtmlBody *string `protobuf:"bytes,1,opt,name=html_body,json=htmlBody" json:"html_body,omitempty"`
+}
+
+func (x *AnswerChoice) Reset() {
+	*x = AnswerChoice{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AnswerChoice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerChoice) ProtoMessage() {}
+
+func (x *AnswerChoice) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerChoice.ProtoReflect.Descriptor instead.
+func (*AnswerChoice) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AnswerChoice) GetHtmlBody() string {
+	if x != nil && x.HtmlBody != nil {
+		return *x.HtmlBody
+	}
+	return ""
+}
+
+type Answer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id             *int64      `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	QuestionId     *int64      `protobuf:"varint,2,opt,name=question_id,json=questionId" json:"question_id,omitempty"`
+	SolverId       *int64      `protobuf:"varint,3,opt,name=solver_id,json=solverId" json:"solver_id,omitempty"`
+	Type           *AnswerType `protobuf:"varint,4,opt,name=type,enum=model.AnswerType" json:"type,omitempty"`
+	AnsText        *string     `protobuf:"bytes,5,opt,name=ans_text,json=ansText" json:"ans_text,omitempty"`
+	AnsLongtext    *string     `protobuf:"bytes,12,opt,name=ans_longtext,json=ansLongtext" json:"ans_longtext,omitempty"`
+	AnsInt         *int64      `protobuf:"varint,6,opt,name=ans_int,json=ansInt" json:"ans_int,omitempty"`
+	AnsFloat       *float32    `protobuf:"fixed32,7,opt,name=ans_float,json=ansFloat" json:"ans_float,omitempty"`
+	AnsBool        *bool       `protobuf:"varint,8,opt,name=ans_bool,json=ansBool" json:"ans_bool,omitempty"`
+	AnsChoiceIndex *int64      `protobuf:"varint,9,opt,name=ans_choice_index,json=ansChoiceIndex" json:"ans_choice_index,omitempty"`
+	ResponseTimeS  *int64      `protobuf:"varint,10,opt,name=response_time_s,json=responseTimeS" json:"response_time_s,omitempty"`
+	PointsAwarded  *int64      `protobuf:"varint,11,opt,name=points_awarded,json=pointsAwarded" json:"points_awarded,omitempty"`
+	// Set when the quizmaster changed the points by hand, so that automatic
+	// grading leaves them alone.
+	PointsOverridden *bool `protobuf:"varint,13,opt,name=points_overridden,json=pointsOverridden" json:"points_overridden,omitempty"`
+	// For TEXT_ANSWER, how the answer matched the answer key when it was graded.
+	TextMatch *TextMatch `protobuf:"varint,14,opt,name=text_match,json=textMatch,enum=model.TextMatch" json:"text_match,omitempty"`
+	// How long after the question was activated the answer was submitted.
+	ElapsedMs *int64 `protobuf:"varint,15,opt,name=elapsed_ms,json=elapsedMs" json:"elapsed_ms,omitempty"`
+	// For MULTI_SELECT_ANSWER, the choices that were picked, in order.
+	AnsChoiceIndices []int64 `protobuf:"varint,16,rep,name=ans_choice_indices,json=ansChoiceIndices" json:"ans_choice_indices,omitempty"`
+	// For ORDERING_ANSWER, the indices of the items in the order they were put in.
+	AnsOrder []int64 `protobuf:"varint,17,rep,name=ans_order,json=ansOrder" json:"ans_order,omitempty"`
+	// For MATCHING_ANSWER, the pairs that were made, in the order of the first column.
+	AnsPairs []*MatchPair `protobuf:"bytes,18,rep,name=ans_pairs,json=ansPairs" json:"ans_pairs,omitempty"`
+	// For CLOZE_ANSWER, what was written in each blank, in order.
+	AnsBlanks []string `protobuf:"bytes,19,rep,name=ans_blanks,json=ansBlanks" json:"ans_blanks,omitempty"`
+	// For UPLOAD_ANSWER, the ID of the Upload holding the file.
+	UploadId *int64 `protobuf:"varint,20,opt,name=upload_id,json=uploadId" json:"upload_id,omitempty"`
+	// For a wager question, the points bet on the answer.
+	Wager *int64 `protobuf:"varint,21,opt,name=wager" json:"wager,omitempty"`
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *Answer) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Answer) GetQuestionId() int64 {
+	if x != nil && x.QuestionId != nil {
+		return *x.QuestionId
+	}
+	return 0
+}
+
+func (x *Answer) GetSolverId() int64 {
+	if x != nil && x.SolverId != nil {
+		return *x.SolverId
+	}
+	return 0
+}
+
+func (x *Answer) GetType() AnswerType {
+	if x != nil && x.Type != nil {
+		return *x.Type
+	}
+	return AnswerType_UNKNOWN_ANSWER_TYPE
+}
+
+func (x *Answer) GetAnsText() string {
+	if x != nil && x.AnsText != nil {
+		return *x.AnsText
+	}
+	return ""
+}
+
+func (x *Answer) GetAnsLongtext() string {
+	if x != nil && x.AnsLongtext != nil {
+		return *x.AnsLongtext
+	}
+	return ""
+}
+
+func (x *Answer) GetAnsInt() int64 {
+	if x != nil && x.AnsInt != nil {
+		return *x.AnsInt
+	}
+	return 0
+}
+
+func (x *Answer) GetAnsFloat() float32 {
+	if x != nil && x.AnsFloat != nil {
+		return *x.AnsFloat
+	}
+	return 0
+}
+
+func (x *Answer) GetAnsBool() bool {
+	if x != nil && x.AnsBool != nil {
+		return *x.AnsBool
+	}
+	return false
+}
+
+func (x *Answer) GetAnsChoiceIndex() int64 {
+	if x != nil && x.AnsChoiceIndex != nil {
+		return *x.AnsChoiceIndex
+	}
+	return 0
+}
+
+func (x *Answer) GetResponseTimeS() int64 {
+	if x != nil && x.ResponseTimeS != nil {
+		return *x.ResponseTimeS
+	}
+	return 0
+}
+
+func (x *Answer) GetPointsAwarded() int64 {
+	if x != nil && x.PointsAwarded != nil {
+		return *x.PointsAwarded
+	}
+	return 0
+}
+
+func (x *Answer) GetPointsOverridden() bool {
+	if x != nil && x.PointsOverridden != nil {
+		return *x.PointsOverridden
+	}
+	return false
+}
+
+func (x *Answer) GetTextMatch() TextMatch {
+	if x != nil && x.TextMatch != nil {
+		return *x.TextMatch
+	}
+	return TextMatch_UNKNOWN_TEXT_MATCH
+}
+
+func (x *Answer) GetElapsedMs() int64 {
+	if x != nil && x.ElapsedMs != nil {
+		return *x.ElapsedMs
+	}
+	return 0
+}
+
+func (x *Answer) GetAnsChoiceIndices() []int64 {
+	if x != nil {
+		return x.AnsChoiceIndices
+	}
+	return nil
+}
+
+func (x *Answer) GetAnsOrder() []int64 {
+	if x != nil {
+		return x.AnsOrder
+	}
+	return nil
+}
+
+func (x *Answer) GetAnsPairs() []*MatchPair {
+	if x != nil {
+		return x.AnsPairs
+	}
+	return nil
+}
+
+func (x *Answer) GetAnsBlanks() []string {
+	if x != nil {
+		return x.AnsBlanks
+	}
+	return nil
+}
+
+func (x *Answer) GetUploadId() int64 {
+	if x != nil && x.UploadId != nil {
+		return *x.UploadId
+	}
+	return 0
+}
+
+func (x *Answer) GetWager() int64 {
+	if x != nil && x.Wager != nil {
+		return *x.Wager
+	}
+	return 0
+}
+
+// Upload is a file sent in by a participant as an answer.
+type Upload struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The quiz it was sent in for. Only the quizmasters of the quiz can see it.
+	QuizId     *int64  `protobuf:"varint,2,opt,name=quiz_id,json=quizId" json:"quiz_id,omitempty"`
+	UploaderId *int64  `protobuf:"varint,3,opt,name=uploader_id,json=uploaderId" json:"uploader_id,omitempty"`
+	MimeType   *string `protobuf:"bytes,4,opt,name=mime_type,json=mimeType" json:"mime_type,omitempty"`
+	Data       []byte  `protobuf:"bytes,5,opt,name=data" json:"data,omitempty"`
+}
+
+func (x *Upload) Reset() {
+	*x = Upload{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Upload) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Upload) ProtoMessage() {}
+
+func (x *Upload) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -584,54 +2029,86 @@ func (x *AnswerChoice) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AnswerChoice.ProtoReflect.Descriptor instead.
-func (*AnswerChoice) Descriptor() ([]byte, []int) {
-	return file_quiz_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use Upload.ProtoReflect.Descriptor instead.
+func (*Upload) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *AnswerChoice) GetHtmlBody() string {
-	if x != nil && x.HtmlBody != nil {
-		return *x.HtmlBody
+func (x *Upload) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
+	}
+	return 0
+}
+
+func (x *Upload) GetQuizId() int64 {
+	if x != nil && x.QuizId != nil {
+		return *x.QuizId
+	}
+	return 0
+}
+
+func (x *Upload) GetUploaderId() int64 {
+	if x != nil && x.UploaderId != nil {
+		return *x.UploaderId
+	}
+	return 0
+}
+
+func (x *Upload) GetMimeType() string {
+	if x != nil && x.MimeType != nil {
+		return *x.MimeType
 	}
 	return ""
 }
 
-type Answer struct {
+func (x *Upload) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ScoreChange records a change to the points of an answer. They are only ever added,
+// never updated, so that disputed scores can be traced.
+type ScoreChange struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id             *int64      `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
-	QuestionId     *int64      `protobuf:"varint,2,opt,name=question_id,json=questionId" json:"question_id,omitempty"`
-	SolverId       *int64      `protobuf:"varint,3,opt,name=solver_id,json=solverId" json:"solver_id,omitempty"`
-	Type           *AnswerType `protobuf:"varint,4,opt,name=type,enum=model.AnswerType" json:"type,omitempty"`
-	AnsText        *string     `protobuf:"bytes,5,opt,name=ans_text,json=ansText" json:"ans_text,omitempty"`
-	AnsLongtext    *string     `protobuf:"bytes,12,opt,name=ans_longtext,json=ansLongtext" json:"ans_longtext,omitempty"`
-	AnsInt         *int64      `protobuf:"varint,6,opt,name=ans_int,json=ansInt" json:"ans_int,omitempty"`
-	AnsFloat       *float32    `protobuf:"fixed32,7,opt,name=ans_float,json=ansFloat" json:"ans_float,omitempty"`
-	AnsBool        *bool       `protobuf:"varint,8,opt,name=ans_bool,json=ansBool" json:"ans_bool,omitempty"`
-	AnsChoiceIndex *int64      `protobuf:"varint,9,opt,name=ans_choice_index,json=ansChoiceIndex" json:"ans_choice_index,omitempty"`
-	ResponseTimeS  *int64      `protobuf:"varint,10,opt,name=response_time_s,json=responseTimeS" json:"response_time_s,omitempty"`
-	PointsAwarded  *int64      `protobuf:"varint,11,opt,name=points_awarded,json=pointsAwarded" json:"points_awarded,omitempty"`
-}
-
-func (x *Answer) Reset() {
-	*x = Answer{}
+	Id         *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	AnswerId   *int64 `protobuf:"varint,2,opt,name=answer_id,json=answerId" json:"answer_id,omitempty"`
+	QuestionId *int64 `protobuf:"varint,3,opt,name=question_id,json=questionId" json:"question_id,omitempty"`
+	QuizId     *int64 `protobuf:"varint,4,opt,name=quiz_id,json=quizId" json:"quiz_id,omitempty"`
+	// The participant who gave the answer.
+	SolverId *int64 `protobuf:"varint,5,opt,name=solver_id,json=solverId" json:"solver_id,omitempty"`
+	// Not set if the answer had not been graded before.
+	OldPoints *int64 `protobuf:"varint,6,opt,name=old_points,json=oldPoints" json:"old_points,omitempty"`
+	NewPoints *int64 `protobuf:"varint,7,opt,name=new_points,json=newPoints" json:"new_points,omitempty"`
+	// The user who changed the points, or 0 if the answer was graded automatically.
+	ChangedBy *int64 `protobuf:"varint,8,opt,name=changed_by,json=changedBy" json:"changed_by,omitempty"`
+	// The name of that user when the points were changed.
+	ChangedByName *string `protobuf:"bytes,9,opt,name=changed_by_name,json=changedByName" json:"changed_by_name,omitempty"`
+	ChangedAtMs   *int64  `protobuf:"varint,10,opt,name=changed_at_ms,json=changedAtMs" json:"changed_at_ms,omitempty"`
+}
+
+func (x *ScoreChange) Reset() {
+	*x = ScoreChange{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_quiz_proto_msgTypes[5]
+		mi := &file_quiz_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *Answer) String() string {
+func (x *ScoreChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Answer) ProtoMessage() {}
+func (*ScoreChange) ProtoMessage() {}
 
-func (x *Answer) ProtoReflect() protoreflect.Message {
-	mi := &file_quiz_proto_msgTypes[5]
+func (x *ScoreChange) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -642,100 +2119,170 @@ func (x *Answer) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
-func (*Answer) Descriptor() ([]byte, []int) {
-	return file_quiz_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ScoreChange.ProtoReflect.Descriptor instead.
+func (*ScoreChange) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *Answer) GetId() int64 {
+func (x *ScoreChange) GetId() int64 {
 	if x != nil && x.Id != nil {
 		return *x.Id
 	}
 	return 0
 }
 
-func (x *Answer) GetQuestionId() int64 {
+func (x *ScoreChange) GetAnswerId() int64 {
+	if x != nil && x.AnswerId != nil {
+		return *x.AnswerId
+	}
+	return 0
+}
+
+func (x *ScoreChange) GetQuestionId() int64 {
 	if x != nil && x.QuestionId != nil {
 		return *x.QuestionId
 	}
 	return 0
 }
 
-func (x *Answer) GetSolverId() int64 {
+func (x *ScoreChange) GetQuizId() int64 {
+	if x != nil && x.QuizId != nil {
+		return *x.QuizId
+	}
+	return 0
+}
+
+func (x *ScoreChange) GetSolverId() int64 {
 	if x != nil && x.SolverId != nil {
 		return *x.SolverId
 	}
 	return 0
 }
 
-func (x *Answer) GetType() AnswerType {
-	if x != nil && x.Type != nil {
-		return *x.Type
+func (x *ScoreChange) GetOldPoints() int64 {
+	if x != nil && x.OldPoints != nil {
+		return *x.OldPoints
 	}
-	return AnswerType_UNKNOWN_ANSWER_TYPE
+	return 0
 }
 
-func (x *Answer) GetAnsText() string {
-	if x != nil && x.AnsText != nil {
-		return *x.AnsText
+func (x *ScoreChange) GetNewPoints() int64 {
+	if x != nil && x.NewPoints != nil {
+		return *x.NewPoints
 	}
-	return ""
+	return 0
 }
 
-func (x *Answer) GetAnsLongtext() string {
-	if x != nil && x.AnsLongtext != nil {
-		return *x.AnsLongtext
+func (x *ScoreChange) GetChangedBy() int64 {
+	if x != nil && x.ChangedBy != nil {
+		return *x.ChangedBy
+	}
+	return 0
+}
+
+func (x *ScoreChange) GetChangedByName() string {
+	if x != nil && x.ChangedByName != nil {
+		return *x.ChangedByName
 	}
 	return ""
 }
 
-func (x *Answer) GetAnsInt() int64 {
-	if x != nil && x.AnsInt != nil {
-		return *x.AnsInt
+func (x *ScoreChange) GetChangedAtMs() int64 {
+	if x != nil && x.ChangedAtMs != nil {
+		return *x.ChangedAtMs
 	}
 	return 0
 }
 
-func (x *Answer) GetAnsFloat() float32 {
-	if x != nil && x.AnsFloat != nil {
-		return *x.AnsFloat
+// BankQuestion is a question in a quizmaster's question bank, to be reused across quizzes.
+type BankQuestion struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id *int64 `protobuf:"varint,1,opt,name=id" json:"id,omitempty"`
+	// The user whose bank the question is in.
+	OwnerId *int64 `protobuf:"varint,2,opt,name=owner_id,json=ownerId" json:"owner_id,omitempty"`
+	// The question itself. Its id, quiz, round and bank fields are not set.
+	Question *Question `protobuf:"bytes,3,opt,name=question" json:"question,omitempty"`
+	Tags     []string  `protobuf:"bytes,4,rep,name=tags" json:"tags,omitempty"`
+	// The quizzes the question was copied or linked into, or saved from, in the order it was
+	// first used in them. A quiz stays in the list even if the question is removed from it.
+	UsedInQuizIds []int64 `protobuf:"varint,5,rep,name=used_in_quiz_ids,json=usedInQuizIds" json:"used_in_quiz_ids,omitempty"`
+}
+
+func (x *BankQuestion) Reset() {
+	*x = BankQuestion{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_quiz_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
 	}
-	return 0
 }
 
-func (x *Answer) GetAnsBool() bool {
-	if x != nil && x.AnsBool != nil {
-		return *x.AnsBool
+func (x *BankQuestion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BankQuestion) ProtoMessage() {}
+
+func (x *BankQuestion) ProtoReflect() protoreflect.Message {
+	mi := &file_quiz_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return false
+	return mi.MessageOf(x)
 }
 
-func (x *Answer) GetAnsChoiceIndex() int64 {
-	if x != nil && x.AnsChoiceIndex != nil {
-		return *x.AnsChoiceIndex
+// Deprecated: Use BankQuestion.ProtoReflect.Descriptor instead.
+func (*BankQuestion) Descriptor() ([]byte, []int) {
+	return file_quiz_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *BankQuestion) GetId() int64 {
+	if x != nil && x.Id != nil {
+		return *x.Id
 	}
 	return 0
 }
 
-func (x *Answer) GetResponseTimeS() int64 {
-	if x != nil && x.ResponseTimeS != nil {
-		return *x.ResponseTimeS
+func (x *BankQuestion) GetOwnerId() int64 {
+	if x != nil && x.OwnerId != nil {
+		return *x.OwnerId
 	}
 	return 0
 }
 
-func (x *Answer) GetPointsAwarded() int64 {
-	if x != nil && x.PointsAwarded != nil {
-		return *x.PointsAwarded
+func (x *BankQuestion) GetQuestion() *Question {
+	if x != nil {
+		return x.Question
 	}
-	return 0
+	return nil
+}
+
+func (x *BankQuestion) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *BankQuestion) GetUsedInQuizIds() []int64 {
+	if x != nil {
+		return x.UsedInQuizIds
+	}
+	return nil
 }
 
 var File_quiz_proto protoreflect.FileDescriptor
 
 var file_quiz_proto_rawDesc = []byte{
 	0x0a, 0x0a, 0x71, 0x75, 0x69, 0x7a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x6d, 0x6f,
-	0x64, 0x65, 0x6c, 0x22, 0x8d, 0x04, 0x0a, 0x04, 0x51, 0x75, 0x69, 0x7a, 0x12, 0x0e, 0x0a, 0x02,
+	0x64, 0x65, 0x6c, 0x22, 0xa4, 0x08, 0x0a, 0x04, 0x51, 0x75, 0x69, 0x7a, 0x12, 0x0e, 0x0a, 0x02,
 	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a, 0x05,
 	0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6d, 0x6f,
 	0x64, 0x65, 0x6c, 0x2e, 0x51, 0x75, 0x69, 0x7a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73,
@@ -768,79 +2315,333 @@ var file_quiz_proto_rawDesc = []byte{
 	0x49, 0x64, 0x12, 0x2f, 0x0a, 0x13, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x5f,
 	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52,
 	0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x73, 0x22, 0x6e, 0x0a, 0x11, 0x51, 0x75, 0x69, 0x7a, 0x6d, 0x61, 0x73, 0x74, 0x65,
-	0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72,
-	0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49,
-	0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x53, 0x75, 0x62,
-	0x12, 0x21, 0x0a, 0x0c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x45, 0x6d,
-	0x61, 0x69, 0x6c, 0x22, 0x87, 0x01, 0x0a, 0x12, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70,
-	0x61, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
-	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
-	0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69,
-	0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x16, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65,
-	0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
-	0x64, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0xe5, 0x01,
-	0x0a, 0x08, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x71, 0x75,
-	0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x71, 0x75, 0x69,
-	0x7a, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x74, 0x6d,
-	0x6c, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x74,
-	0x6d, 0x6c, 0x42, 0x6f, 0x64, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73,
-	0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a,
-	0x07, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
-	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43, 0x68, 0x6f,
-	0x69, 0x63, 0x65, 0x52, 0x07, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x07,
-	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e,
-	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x61, 0x6e,
-	0x73, 0x77, 0x65, 0x72, 0x73, 0x22, 0x2b, 0x0a, 0x0c, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43,
-	0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x74, 0x6d, 0x6c, 0x5f, 0x62, 0x6f,
-	0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x74, 0x6d, 0x6c, 0x42, 0x6f,
-	0x64, 0x79, 0x22, 0x85, 0x03, 0x0a, 0x06, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x0e, 0x0a,
-	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a,
-	0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1b,
-	0x0a, 0x09, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x08, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74,
-	0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
-	0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
-	0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x54, 0x65, 0x78, 0x74, 0x12, 0x21, 0x0a,
-	0x0c, 0x61, 0x6e, 0x73, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0c, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6e, 0x73, 0x4c, 0x6f, 0x6e, 0x67, 0x74, 0x65, 0x78, 0x74,
-	0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x73, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x49, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6e, 0x73,
-	0x5f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x61, 0x6e,
-	0x73, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x5f, 0x62, 0x6f,
-	0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x42, 0x6f, 0x6f,
-	0x6c, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x6e, 0x73, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x5f,
-	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x6e, 0x73,
-	0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x26, 0x0a, 0x0f, 0x72,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69,
-	0x6d, 0x65, 0x53, 0x12, 0x25, 0x0a, 0x0e, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x61, 0x77,
-	0x61, 0x72, 0x64, 0x65, 0x64, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x6f, 0x69,
-	0x6e, 0x74, 0x73, 0x41, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x2a, 0x42, 0x0a, 0x09, 0x51, 0x75,
-	0x69, 0x7a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f,
-	0x57, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x00, 0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45,
-	0x57, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45, 0x44, 0x10, 0x02,
-	0x12, 0x0c, 0x0a, 0x08, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x9d,
-	0x01, 0x0a, 0x0a, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a,
-	0x13, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f,
-	0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x41,
-	0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x54, 0x36, 0x34,
-	0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x02, 0x12, 0x10, 0x0a, 0x0c, 0x46, 0x4c, 0x4f,
-	0x41, 0x54, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x42,
-	0x4f, 0x4f, 0x4c, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16,
-	0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x45, 0x5f, 0x43, 0x48, 0x4f, 0x49, 0x43, 0x45, 0x5f,
-	0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x05, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x4f, 0x4e, 0x47,
-	0x5f, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x06, 0x42, 0x10,
-	0x5a, 0x0e, 0x71, 0x75, 0x69, 0x7a, 0x64, 0x72, 0x75, 0x6d, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
+	0x73, 0x65, 0x73, 0x12, 0x30, 0x0a, 0x14, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f,
+	0x64, 0x65, 0x61, 0x64, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x12, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x65, 0x61, 0x64, 0x6c,
+	0x69, 0x6e, 0x65, 0x4d, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x74, 0x6f, 0x5f, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x75, 0x74, 0x6f, 0x53,
+	0x74, 0x61, 0x72, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x72,
+	0x65, 0x76, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e, 0x61,
+	0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x65, 0x64, 0x12, 0x58, 0x0a,
+	0x15, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x24, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x51, 0x75, 0x69, 0x7a, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x13, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63, 0x74, 0x69,
+	0x76, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x73, 0x70, 0x65, 0x65, 0x64,
+	0x5f, 0x62, 0x6f, 0x6e, 0x75, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x53, 0x70, 0x65, 0x65, 0x64, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x52,
+	0x0a, 0x73, 0x70, 0x65, 0x65, 0x64, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x0b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63,
+	0x61, 0x6c, 0x65, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64,
+	0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x45, 0x6e, 0x61,
+	0x62, 0x6c, 0x65, 0x64, 0x12, 0x24, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x18, 0x14,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x69,
+	0x76, 0x65, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x6c, 0x69, 0x76, 0x65, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x12, 0x22,
+	0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18,
+	0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c, 0x61, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x49, 0x64, 0x1a, 0x46, 0x0a, 0x18, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x41, 0x63,
+	0x74, 0x69, 0x76, 0x61, 0x74, 0x65, 0x64, 0x4d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x80, 0x01, 0x0a, 0x05, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x74,
+	0x6d, 0x6c, 0x5f, 0x69, 0x6e, 0x74, 0x72, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x68, 0x74, 0x6d, 0x6c, 0x49, 0x6e, 0x74, 0x72, 0x6f, 0x12, 0x32, 0x0a, 0x0b, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c,
+	0x65, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x22, 0x7a, 0x0a,
+	0x0a, 0x53, 0x70, 0x65, 0x65, 0x64, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x66,
+	0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x53, 0x70, 0x65, 0x65, 0x64, 0x42, 0x6f, 0x6e, 0x75, 0x73, 0x46,
+	0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x52, 0x07, 0x66, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x19,
+	0x0a, 0x08, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x07, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x22, 0x6e, 0x0a, 0x11, 0x51, 0x75, 0x69,
+	0x7a, 0x6d, 0x61, 0x73, 0x74, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x17,
+	0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x5f, 0x73, 0x75, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x53, 0x75, 0x62, 0x12, 0x21, 0x0a, 0x0c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65,
+	0x5f, 0x65, 0x6d, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x45, 0x6d, 0x61, 0x69, 0x6c, 0x22, 0xad, 0x01, 0x0a, 0x12, 0x50, 0x61,
+	0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x35, 0x0a, 0x16,
+	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x63, 0x6f,
+	0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x24, 0x0a, 0x0e, 0x6a, 0x6f, 0x6b, 0x65, 0x72, 0x5f, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6a, 0x6f, 0x6b,
+	0x65, 0x72, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x49, 0x64, 0x22, 0x85, 0x05, 0x0a, 0x08, 0x51, 0x75,
+	0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x7a, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x71, 0x75, 0x69, 0x7a, 0x49, 0x64, 0x12,
+	0x14, 0x0a, 0x05, 0x74, 0x69, 0x74, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x74, 0x69, 0x74, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x68, 0x74, 0x6d, 0x6c, 0x5f, 0x62, 0x6f,
+	0x64, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x68, 0x74, 0x6d, 0x6c, 0x42, 0x6f,
+	0x64, 0x79, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x54,
+	0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x2d, 0x0a, 0x07, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d, 0x6f, 0x64,
+	0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x52,
+	0x07, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x12, 0x27, 0x0a, 0x07, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x73, 0x12, 0x20, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x53, 0x12, 0x23, 0x0a, 0x0d, 0x68, 0x74, 0x6d, 0x6c, 0x5f, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x68, 0x74, 0x6d, 0x6c,
+	0x53, 0x6f, 0x6c, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2f, 0x0a, 0x0a, 0x61, 0x6e, 0x73, 0x77,
+	0x65, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x52, 0x09,
+	0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x12, 0x32, 0x0a, 0x0b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x73, 0x63, 0x61, 0x6c, 0x65,
+	0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x65, 0x52, 0x0a, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x53, 0x63, 0x61, 0x6c, 0x65, 0x12, 0x38, 0x0a, 0x0d, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x5f, 0x63,
+	0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x6d,
+	0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43, 0x68, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x0c, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x14, 0x0a, 0x05, 0x77, 0x61, 0x67, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05,
+	0x77, 0x61, 0x67, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x69, 0x65, 0x5f, 0x62, 0x72, 0x65,
+	0x61, 0x6b, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x74, 0x69, 0x65, 0x42,
+	0x72, 0x65, 0x61, 0x6b, 0x65, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f,
+	0x69, 0x64, 0x18, 0x10, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x49,
+	0x64, 0x12, 0x28, 0x0a, 0x10, 0x62, 0x61, 0x6e, 0x6b, 0x5f, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x62, 0x61, 0x6e,
+	0x6b, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x6c,
+	0x69, 0x6e, 0x6b, 0x65, 0x64, 0x5f, 0x74, 0x6f, 0x5f, 0x62, 0x61, 0x6e, 0x6b, 0x18, 0x12, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0c, 0x6c, 0x69, 0x6e, 0x6b, 0x65, 0x64, 0x54, 0x6f, 0x42, 0x61, 0x6e,
+	0x6b, 0x22, 0x94, 0x01, 0x0a, 0x0a, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x53, 0x63, 0x61, 0x6c, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x07, 0x70, 0x72, 0x65, 0x73, 0x65, 0x74, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x77, 0x72,
+	0x6f, 0x6e, 0x67, 0x5f, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x77, 0x72, 0x6f, 0x6e, 0x67, 0x41, 0x6e,
+	0x73, 0x77, 0x65, 0x72, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69,
+	0x6e, 0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09,
+	0x6d, 0x69, 0x6e, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78,
+	0x5f, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d,
+	0x61, 0x78, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x97, 0x06, 0x0a, 0x09, 0x41, 0x6e, 0x73,
+	0x77, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74,
+	0x65, 0x64, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d,
+	0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x54, 0x65, 0x78, 0x74, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x69, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x74, 0x12, 0x21,
+	0x0a, 0x0c, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x42, 0x6f, 0x6f,
+	0x6c, 0x12, 0x30, 0x0a, 0x14, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x68, 0x6f,
+	0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x12, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x12, 0x3e, 0x0a,
+	0x0d, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54, 0x65, 0x78,
+	0x74, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0d,
+	0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2a, 0x0a,
+	0x11, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x64, 0x69, 0x74, 0x5f, 0x64, 0x69, 0x73, 0x74, 0x61, 0x6e,
+	0x63, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61, 0x78, 0x45, 0x64, 0x69,
+	0x74, 0x44, 0x69, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x5f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0c, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12, 0x1b,
+	0x0a, 0x09, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x5f, 0x6d, 0x69, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x01, 0x52, 0x08, 0x72, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x69, 0x6e, 0x12, 0x1b, 0x0a, 0x09, 0x72,
+	0x61, 0x6e, 0x67, 0x65, 0x5f, 0x6d, 0x61, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08,
+	0x72, 0x61, 0x6e, 0x67, 0x65, 0x4d, 0x61, 0x78, 0x12, 0x3d, 0x0a, 0x0f, 0x74, 0x6f, 0x6c, 0x65,
+	0x72, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61,
+	0x6e, 0x63, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x52, 0x0e, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e,
+	0x63, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x34, 0x0a, 0x16, 0x63, 0x6f, 0x72, 0x72, 0x65,
+	0x63, 0x74, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65,
+	0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x03, 0x52, 0x14, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74,
+	0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x4b, 0x0a,
+	0x14, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x5f, 0x67, 0x72,
+	0x61, 0x64, 0x69, 0x6e, 0x67, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x6d, 0x6f,
+	0x64, 0x65, 0x6c, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47,
+	0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x52, 0x12, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x6c,
+	0x65, 0x63, 0x74, 0x47, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x6f,
+	0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x0c, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12,
+	0x41, 0x0a, 0x10, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x67, 0x72, 0x61, 0x64,
+	0x69, 0x6e, 0x67, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x2e, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x61, 0x64, 0x69, 0x6e,
+	0x67, 0x52, 0x0f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x61, 0x64, 0x69,
+	0x6e, 0x67, 0x12, 0x35, 0x0a, 0x0d, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x5f, 0x70, 0x61,
+	0x69, 0x72, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x69, 0x72, 0x52, 0x0c, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x63, 0x74, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x27, 0x0a, 0x06, 0x62, 0x6c, 0x61,
+	0x6e, 0x6b, 0x73, 0x18, 0x11, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x6d, 0x6f, 0x64, 0x65,
+	0x6c, 0x2e, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x4b, 0x65, 0x79, 0x52, 0x06, 0x62, 0x6c, 0x61, 0x6e,
+	0x6b, 0x73, 0x22, 0x49, 0x0a, 0x08, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x4b, 0x65, 0x79, 0x12, 0x25,
+	0x0a, 0x0e, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64,
+	0x54, 0x65, 0x78, 0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x22, 0x35, 0x0a,
+	0x09, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x69, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6c, 0x65,
+	0x66, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x6c, 0x65, 0x66, 0x74, 0x12, 0x14,
+	0x0a, 0x05, 0x72, 0x69, 0x67, 0x68, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x72,
+	0x69, 0x67, 0x68, 0x74, 0x22, 0x82, 0x01, 0x0a, 0x0d, 0x54, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e,
+	0x63, 0x65, 0x42, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x61, 0x62, 0x73, 0x6f, 0x6c, 0x75,
+	0x74, 0x65, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x70,
+	0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x72, 0x65,
+	0x6c, 0x61, 0x74, 0x69, 0x76, 0x65, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x2a, 0x0a,
+	0x0e, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x3a, 0x03, 0x31, 0x30, 0x30, 0x52, 0x0d, 0x70, 0x6f, 0x69, 0x6e,
+	0x74, 0x73, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x22, 0xf2, 0x01, 0x0a, 0x11, 0x54, 0x65,
+	0x78, 0x74, 0x4e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x25, 0x0a, 0x0b, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x61, 0x73, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x0a, 0x69, 0x67, 0x6e, 0x6f,
+	0x72, 0x65, 0x43, 0x61, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x11, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65,
+	0x5f, 0x77, 0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x3a, 0x04, 0x74, 0x72, 0x75, 0x65, 0x52, 0x10, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x57,
+	0x68, 0x69, 0x74, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x69, 0x67, 0x6e,
+	0x6f, 0x72, 0x65, 0x5f, 0x70, 0x75, 0x6e, 0x63, 0x74, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x50, 0x75, 0x6e,
+	0x63, 0x74, 0x75, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x69, 0x67, 0x6e, 0x6f,
+	0x72, 0x65, 0x5f, 0x64, 0x69, 0x61, 0x63, 0x72, 0x69, 0x74, 0x69, 0x63, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x10, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x44, 0x69, 0x61, 0x63, 0x72,
+	0x69, 0x74, 0x69, 0x63, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x5f,
+	0x61, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0e,
+	0x69, 0x67, 0x6e, 0x6f, 0x72, 0x65, 0x41, 0x72, 0x74, 0x69, 0x63, 0x6c, 0x65, 0x73, 0x22, 0x2b,
+	0x0a, 0x0c, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1b,
+	0x0a, 0x09, 0x68, 0x74, 0x6d, 0x6c, 0x5f, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x08, 0x68, 0x74, 0x6d, 0x6c, 0x42, 0x6f, 0x64, 0x79, 0x22, 0xce, 0x05, 0x0a, 0x06,
+	0x41, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x6c, 0x76, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x6f, 0x6c, 0x76,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x11, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x41, 0x6e, 0x73, 0x77, 0x65,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x61,
+	0x6e, 0x73, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x6e, 0x73, 0x54, 0x65, 0x78, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x6e, 0x73, 0x5f, 0x6c, 0x6f,
+	0x6e, 0x67, 0x74, 0x65, 0x78, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x6e,
+	0x73, 0x4c, 0x6f, 0x6e, 0x67, 0x74, 0x65, 0x78, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x61, 0x6e, 0x73,
+	0x5f, 0x69, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6e, 0x73, 0x49,
+	0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6e, 0x73, 0x5f, 0x66, 0x6c, 0x6f, 0x61, 0x74, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x02, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x46, 0x6c, 0x6f, 0x61, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x61, 0x6e, 0x73, 0x5f, 0x62, 0x6f, 0x6f, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x61, 0x6e, 0x73, 0x42, 0x6f, 0x6f, 0x6c, 0x12, 0x28, 0x0a, 0x10, 0x61, 0x6e,
+	0x73, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x09,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x61, 0x6e, 0x73, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x26, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x53, 0x12, 0x25, 0x0a, 0x0e,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x61, 0x77, 0x61, 0x72, 0x64, 0x65, 0x64, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x41, 0x77, 0x61, 0x72,
+	0x64, 0x65, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x5f, 0x6f, 0x76,
+	0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x10,
+	0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x4f, 0x76, 0x65, 0x72, 0x72, 0x69, 0x64, 0x64, 0x65, 0x6e,
+	0x12, 0x2f, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74, 0x5f, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x54, 0x65, 0x78,
+	0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x4d, 0x61, 0x74, 0x63,
+	0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x5f, 0x6d, 0x73, 0x18,
+	0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x6c, 0x61, 0x70, 0x73, 0x65, 0x64, 0x4d, 0x73,
+	0x12, 0x2c, 0x0a, 0x12, 0x61, 0x6e, 0x73, 0x5f, 0x63, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x5f, 0x69,
+	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x10, 0x20, 0x03, 0x28, 0x03, 0x52, 0x10, 0x61, 0x6e,
+	0x73, 0x43, 0x68, 0x6f, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x1b,
+	0x0a, 0x09, 0x61, 0x6e, 0x73, 0x5f, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x11, 0x20, 0x03, 0x28,
+	0x03, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x2d, 0x0a, 0x09, 0x61,
+	0x6e, 0x73, 0x5f, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10,
+	0x2e, 0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x50, 0x61, 0x69, 0x72,
+	0x52, 0x08, 0x61, 0x6e, 0x73, 0x50, 0x61, 0x69, 0x72, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x6e,
+	0x73, 0x5f, 0x62, 0x6c, 0x61, 0x6e, 0x6b, 0x73, 0x18, 0x13, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x61, 0x6e, 0x73, 0x42, 0x6c, 0x61, 0x6e, 0x6b, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x61, 0x67, 0x65, 0x72, 0x18,
+	0x15, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x77, 0x61, 0x67, 0x65, 0x72, 0x22, 0x83, 0x01, 0x0a,
+	0x06, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x7a, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x71, 0x75, 0x69, 0x7a, 0x49, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x65, 0x72, 0x49,
+	0x64, 0x12, 0x1b, 0x0a, 0x09, 0x6d, 0x69, 0x6d, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x69, 0x6d, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x22, 0xba, 0x02, 0x0a, 0x0b, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x43, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x61, 0x6e, 0x73, 0x77, 0x65, 0x72, 0x49, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x71, 0x75, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x06, 0x71, 0x75, 0x69, 0x7a, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x6f, 0x6c,
+	0x76, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x6f,
+	0x6c, 0x76, 0x65, 0x72, 0x49, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6f, 0x6c, 0x64, 0x50,
+	0x6f, 0x69, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6e, 0x65, 0x77, 0x5f, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6e, 0x65, 0x77, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f,
+	0x62, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65,
+	0x64, 0x42, 0x79, 0x12, 0x26, 0x0a, 0x0f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x62,
+	0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x64, 0x42, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x5f, 0x6d, 0x73, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x64, 0x41, 0x74, 0x4d, 0x73, 0x22,
+	0xa3, 0x01, 0x0a, 0x0c, 0x42, 0x61, 0x6e, 0x6b, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x19, 0x0a, 0x08, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x07, 0x6f, 0x77, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x08, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0f, 0x2e,
+	0x6d, 0x6f, 0x64, 0x65, 0x6c, 0x2e, 0x51, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73,
+	0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x27, 0x0a, 0x10,
+	0x75, 0x73, 0x65, 0x64, 0x5f, 0x69, 0x6e, 0x5f, 0x71, 0x75, 0x69, 0x7a, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x03, 0x52, 0x0d, 0x75, 0x73, 0x65, 0x64, 0x49, 0x6e, 0x51, 0x75,
+	0x69, 0x7a, 0x49, 0x64, 0x73, 0x2a, 0x39, 0x0a, 0x11, 0x53, 0x70, 0x65, 0x65, 0x64, 0x42, 0x6f,
+	0x6e, 0x75, 0x73, 0x46, 0x6f, 0x72, 0x6d, 0x75, 0x6c, 0x61, 0x12, 0x12, 0x0a, 0x0e, 0x4e, 0x4f,
+	0x5f, 0x53, 0x50, 0x45, 0x45, 0x44, 0x5f, 0x42, 0x4f, 0x4e, 0x55, 0x53, 0x10, 0x00, 0x12, 0x10,
+	0x0a, 0x0c, 0x4c, 0x49, 0x4e, 0x45, 0x41, 0x52, 0x5f, 0x44, 0x45, 0x43, 0x41, 0x59, 0x10, 0x01,
+	0x2a, 0x42, 0x0a, 0x09, 0x51, 0x75, 0x69, 0x7a, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x11, 0x0a,
+	0x0d, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x10, 0x00,
+	0x12, 0x07, 0x0a, 0x03, 0x4e, 0x45, 0x57, 0x10, 0x01, 0x12, 0x0b, 0x0a, 0x07, 0x53, 0x54, 0x41,
+	0x52, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x41, 0x52, 0x43, 0x48, 0x49, 0x56,
+	0x45, 0x44, 0x10, 0x03, 0x2a, 0x4a, 0x0a, 0x0f, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x69, 0x6e, 0x67,
+	0x47, 0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58, 0x41, 0x43, 0x54,
+	0x5f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x10, 0x00, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x4f, 0x53, 0x49,
+	0x54, 0x49, 0x4f, 0x4e, 0x53, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43, 0x54, 0x10, 0x01, 0x12,
+	0x0f, 0x0a, 0x0b, 0x4b, 0x45, 0x4e, 0x44, 0x41, 0x4c, 0x4c, 0x5f, 0x54, 0x41, 0x55, 0x10, 0x02,
+	0x2a, 0x5e, 0x0a, 0x12, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x65, 0x6c, 0x65, 0x63, 0x74, 0x47,
+	0x72, 0x61, 0x64, 0x69, 0x6e, 0x67, 0x12, 0x12, 0x0a, 0x0e, 0x41, 0x4c, 0x4c, 0x5f, 0x4f, 0x52,
+	0x5f, 0x4e, 0x4f, 0x54, 0x48, 0x49, 0x4e, 0x47, 0x10, 0x00, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x45,
+	0x52, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43, 0x54, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e,
+	0x10, 0x01, 0x12, 0x1c, 0x0a, 0x18, 0x50, 0x45, 0x4e, 0x41, 0x4c, 0x54, 0x59, 0x5f, 0x50, 0x45,
+	0x52, 0x5f, 0x57, 0x52, 0x4f, 0x4e, 0x47, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x10, 0x02,
+	0x2a, 0x53, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x4d, 0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a,
+	0x12, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x45, 0x58, 0x41, 0x43, 0x54, 0x5f, 0x4d,
+	0x41, 0x54, 0x43, 0x48, 0x10, 0x01, 0x12, 0x0f, 0x0a, 0x0b, 0x46, 0x55, 0x5a, 0x5a, 0x59, 0x5f,
+	0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x02, 0x12, 0x0c, 0x0a, 0x08, 0x4e, 0x4f, 0x5f, 0x4d, 0x41,
+	0x54, 0x43, 0x48, 0x10, 0x03, 0x2a, 0x85, 0x02, 0x0a, 0x0a, 0x41, 0x6e, 0x73, 0x77, 0x65, 0x72,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x17, 0x0a, 0x13, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x5f,
+	0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x10, 0x00, 0x12, 0x0f, 0x0a,
+	0x0b, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x01, 0x12, 0x10,
+	0x0a, 0x0c, 0x49, 0x4e, 0x54, 0x36, 0x34, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x02,
+	0x12, 0x10, 0x0a, 0x0c, 0x46, 0x4c, 0x4f, 0x41, 0x54, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52,
+	0x10, 0x03, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4f, 0x4f, 0x4c, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45,
+	0x52, 0x10, 0x04, 0x12, 0x1a, 0x0a, 0x16, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x50, 0x4c, 0x45, 0x5f,
+	0x43, 0x48, 0x4f, 0x49, 0x43, 0x45, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x05, 0x12,
+	0x14, 0x0a, 0x10, 0x4c, 0x4f, 0x4e, 0x47, 0x5f, 0x54, 0x45, 0x58, 0x54, 0x5f, 0x41, 0x4e, 0x53,
+	0x57, 0x45, 0x52, 0x10, 0x06, 0x12, 0x17, 0x0a, 0x13, 0x4d, 0x55, 0x4c, 0x54, 0x49, 0x5f, 0x53,
+	0x45, 0x4c, 0x45, 0x43, 0x54, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x07, 0x12, 0x13,
+	0x0a, 0x0f, 0x4f, 0x52, 0x44, 0x45, 0x52, 0x49, 0x4e, 0x47, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45,
+	0x52, 0x10, 0x08, 0x12, 0x13, 0x0a, 0x0f, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x49, 0x4e, 0x47, 0x5f,
+	0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x09, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x4c, 0x4f, 0x5a,
+	0x45, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x0a, 0x12, 0x11, 0x0a, 0x0d, 0x55, 0x50,
+	0x4c, 0x4f, 0x41, 0x44, 0x5f, 0x41, 0x4e, 0x53, 0x57, 0x45, 0x52, 0x10, 0x0b, 0x42, 0x10, 0x5a,
+	0x0e, 0x71, 0x75, 0x69, 0x7a, 0x64, 0x72, 0x75, 0x6d, 0x2f, 0x6d, 0x6f, 0x64, 0x65, 0x6c,
 }
 
 var (
@@ -855,32 +2656,66 @@ func file_quiz_proto_rawDescGZIP() []byte {
 	return file_quiz_proto_rawDescData
 }
 
-var file_quiz_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_quiz_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_quiz_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_quiz_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_quiz_proto_goTypes = []interface{}{
-	(QuizState)(0),             // 0: model.QuizState
-	(AnswerType)(0),            // 1: model.AnswerType
-	(*Quiz)(nil),               // 2: model.Quiz
-	(*QuizmasterProfile)(nil),  // 3: model.QuizmasterProfile
-	(*ParticipantProfile)(nil), // 4: model.ParticipantProfile
-	(*Question)(nil),           // 5: model.Question
-	(*AnswerChoice)(nil),       // 6: model.AnswerChoice
-	(*Answer)(nil),             // 7: model.Answer
+	(SpeedBonusFormula)(0),     // 0: model.SpeedBonusFormula
+	(QuizState)(0),             // 1: model.QuizState
+	(OrderingGrading)(0),       // 2: model.OrderingGrading
+	(MultiSelectGrading)(0),    // 3: model.MultiSelectGrading
+	(TextMatch)(0),             // 4: model.TextMatch
+	(AnswerType)(0),            // 5: model.AnswerType
+	(*Quiz)(nil),               // 6: model.Quiz
+	(*Round)(nil),              // 7: model.Round
+	(*SpeedBonus)(nil),         // 8: model.SpeedBonus
+	(*QuizmasterProfile)(nil),  // 9: model.QuizmasterProfile
+	(*ParticipantProfile)(nil), // 10: model.ParticipantProfile
+	(*Question)(nil),           // 11: model.Question
+	(*PointScale)(nil),         // 12: model.PointScale
+	(*AnswerKey)(nil),          // 13: model.AnswerKey
+	(*BlankKey)(nil),           // 14: model.BlankKey
+	(*MatchPair)(nil),          // 15: model.MatchPair
+	(*ToleranceBand)(nil),      // 16: model.ToleranceBand
+	(*TextNormalization)(nil),  // 17: model.TextNormalization
+	(*AnswerChoice)(nil),       // 18: model.AnswerChoice
+	(*Answer)(nil),             // 19: model.Answer
+	(*Upload)(nil),             // 20: model.Upload
+	(*ScoreChange)(nil),        // 21: model.ScoreChange
+	(*BankQuestion)(nil),       // 22: model.BankQuestion
+	nil,                        // 23: model.Quiz.QuestionActivatedMsEntry
 }
 var file_quiz_proto_depIdxs = []int32{
-	0, // 0: model.Quiz.state:type_name -> model.QuizState
-	5, // 1: model.Quiz.questions:type_name -> model.Question
-	3, // 2: model.Quiz.quizmasters:type_name -> model.QuizmasterProfile
-	4, // 3: model.Quiz.participants:type_name -> model.ParticipantProfile
-	1, // 4: model.Question.type:type_name -> model.AnswerType
-	6, // 5: model.Question.choices:type_name -> model.AnswerChoice
-	7, // 6: model.Question.answers:type_name -> model.Answer
-	1, // 7: model.Answer.type:type_name -> model.AnswerType
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	1,  // 0: model.Quiz.state:type_name -> model.QuizState
+	11, // 1: model.Quiz.questions:type_name -> model.Question
+	9,  // 2: model.Quiz.quizmasters:type_name -> model.QuizmasterProfile
+	10, // 3: model.Quiz.participants:type_name -> model.ParticipantProfile
+	23, // 4: model.Quiz.question_activated_ms:type_name -> model.Quiz.QuestionActivatedMsEntry
+	8,  // 5: model.Quiz.speed_bonus:type_name -> model.SpeedBonus
+	12, // 6: model.Quiz.point_scale:type_name -> model.PointScale
+	7,  // 7: model.Quiz.rounds:type_name -> model.Round
+	12, // 8: model.Round.point_scale:type_name -> model.PointScale
+	0,  // 9: model.SpeedBonus.formula:type_name -> model.SpeedBonusFormula
+	5,  // 10: model.Question.type:type_name -> model.AnswerType
+	18, // 11: model.Question.choices:type_name -> model.AnswerChoice
+	19, // 12: model.Question.answers:type_name -> model.Answer
+	13, // 13: model.Question.answer_key:type_name -> model.AnswerKey
+	12, // 14: model.Question.point_scale:type_name -> model.PointScale
+	18, // 15: model.Question.match_choices:type_name -> model.AnswerChoice
+	17, // 16: model.AnswerKey.normalization:type_name -> model.TextNormalization
+	16, // 17: model.AnswerKey.tolerance_bands:type_name -> model.ToleranceBand
+	3,  // 18: model.AnswerKey.multi_select_grading:type_name -> model.MultiSelectGrading
+	2,  // 19: model.AnswerKey.ordering_grading:type_name -> model.OrderingGrading
+	15, // 20: model.AnswerKey.correct_pairs:type_name -> model.MatchPair
+	14, // 21: model.AnswerKey.blanks:type_name -> model.BlankKey
+	5,  // 22: model.Answer.type:type_name -> model.AnswerType
+	4,  // 23: model.Answer.text_match:type_name -> model.TextMatch
+	15, // 24: model.Answer.ans_pairs:type_name -> model.MatchPair
+	11, // 25: model.BankQuestion.question:type_name -> model.Question
+	26, // [26:26] is the sub-list for method output_type
+	26, // [26:26] is the sub-list for method input_type
+	26, // [26:26] is the sub-list for extension type_name
+	26, // [26:26] is the sub-list for extension extendee
+	0,  // [0:26] is the sub-list for field type_name
 }
 
 func init() { file_quiz_proto_init() }
@@ -902,7 +2737,7 @@ func file_quiz_proto_init() {
 			}
 		}
 		file_quiz_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*QuizmasterProfile); i {
+			switch v := v.(*Round); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -914,7 +2749,7 @@ func file_quiz_proto_init() {
 			}
 		}
 		file_quiz_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ParticipantProfile); i {
+			switch v := v.(*SpeedBonus); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -926,7 +2761,7 @@ func file_quiz_proto_init() {
 			}
 		}
 		file_quiz_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Question); i {
+			switch v := v.(*QuizmasterProfile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -938,7 +2773,7 @@ func file_quiz_proto_init() {
 			}
 		}
 		file_quiz_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*AnswerChoice); i {
+			switch v := v.(*ParticipantProfile); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -950,6 +2785,102 @@ func file_quiz_proto_init() {
 			}
 		}
 		file_quiz_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Question); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PointScale); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnswerKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlankKey); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MatchPair); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ToleranceBand); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TextNormalization); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AnswerChoice); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Answer); i {
 			case 0:
 				return &v.state
@@ -961,14 +2892,50 @@ func file_quiz_proto_init() {
 				return nil
 			}
 		}
+		file_quiz_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Upload); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ScoreChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_quiz_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BankQuestion); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_quiz_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   6,
+			NumEnums:      6,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
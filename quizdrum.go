@@ -62,11 +62,18 @@ func main() {
 		P: &p,
 		V: &v,
 	}
+	if err = c.Initialize(); err != nil {
+		log.Fatal(err)
+	}
 
 	r := mux.NewRouter()
 	r.HandleFunc("/quizmaster/quiz/{quizid}/edit", c.QmEditQuiz)
 	r.HandleFunc("/quizmaster/quiz/{quizid}/live", c.QmLive)
+	r.HandleFunc("/quizmaster/quiz/{quizid}/present", c.QmPresent)
 	r.HandleFunc("/quizmaster/quiz/{quizid}/scoreboard", c.RenderQMScoreboard)
+	r.HandleFunc("/quizmaster/quiz/{quizid}/scorelog/question/{questionid}", c.RenderQuestionScoreLog)
+	r.HandleFunc("/quizmaster/quiz/{quizid}/scorelog/participant/{participantid}", c.RenderParticipantScoreLog)
+	r.HandleFunc("/quizmaster/bank", c.QmBank)
 	r.HandleFunc("/participant/quiz/{quizid}/createprofile", c.RenderCreateProfile)
 	r.HandleFunc("/participant/quiz/{quizid}/live", c.RenderLiveQuiz)
 	r.HandleFunc("/participant/quiz/{quizid}/scoreboard", c.RenderScoreboard)
@@ -74,19 +81,34 @@ func main() {
 	r.HandleFunc("/api/quizmaster/newquiz", c.NewQuiz).Methods("POST")
 	r.HandleFunc("/api/quizmaster/quiz/{quizid}/setactive/{questionid}", c.SetActiveQuestionID).Methods("POST")
 	r.HandleFunc("/api/quizmaster/quiz/{quizid}/setacceptingresponses", c.SetAcceptingResponses).Methods("POST")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/reveal", c.SetAnswerRevealed).Methods("POST")
 	r.HandleFunc("/api/quizmaster/quiz/{quizid}/updateproperties", c.UpdateQuizProperties).Methods("PUT")
 	r.HandleFunc("/api/quizmaster/quiz/{quizid}/delete", c.DeleteQuiz).Methods("DELETE")
 	r.HandleFunc("/api/quizmaster/quiz/{quizid}/reinstate", c.ReinstateQuiz).Methods("PUT")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/answerfeed", c.QmAnswerFeed).Methods("GET")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/regrade", c.RegradeAnswers).Methods("POST")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/reorder", c.ReorderQuestions).Methods("POST")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/showround/{roundid}", c.ShowRound).Methods("POST")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/round/new", c.NewRound).Methods("POST")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/round/{roundid}/update", c.UpdateRound).Methods("PUT")
+	r.HandleFunc("/api/quizmaster/quiz/{quizid}/round/{roundid}/delete", c.DeleteRound).Methods("DELETE")
 	r.HandleFunc("/api/quizmaster/question/new", c.NewQuestion).Methods("POST")
 	r.HandleFunc("/api/quizmaster/question/{questionid}", c.GetQuestion).Methods("GET")
 	r.HandleFunc("/api/quizmaster/question/{questionid}/delete", c.DeleteQuestion).Methods("DELETE")
 	r.HandleFunc("/api/quizmaster/question/{questionid}/update", c.UpdateQuestion).Methods("PUT")
 	r.HandleFunc("/api/quizmaster/question/{questionid}/getallanswers", c.GetAllAnswersForQuestion).Methods("GET")
 	r.HandleFunc("/api/quizmaster/question/{questionid}/savescores", c.SaveScores).Methods("POST")
+	r.HandleFunc("/api/quizmaster/upload/{uploadid}", c.GetUpload).Methods("GET")
+	r.HandleFunc("/api/quizmaster/bank/save", c.SaveQuestionToBank).Methods("POST")
+	r.HandleFunc("/api/quizmaster/bank/{bankid}/addtoquiz", c.AddBankQuestionToQuiz).Methods("POST")
+	r.HandleFunc("/api/quizmaster/bank/{bankid}/tags", c.UpdateBankQuestionTags).Methods("PUT")
+	r.HandleFunc("/api/quizmaster/bank/{bankid}/delete", c.DeleteBankQuestion).Methods("DELETE")
 
 	r.HandleFunc("/api/participant/set-profile", c.SetProfile).Methods("POST")
 	r.HandleFunc("/api/participant/submit-answer", c.SubmitAnswer).Methods("POST")
+	r.HandleFunc("/api/participant/play-joker", c.PlayJoker).Methods("POST")
 	r.HandleFunc("/api/participant/quiz/{quizid}/getstatus", c.GetQuizStatus).Methods("GET")
+	r.HandleFunc("/api/participant/quiz/{quizid}/statusstream", c.StreamQuizStatus).Methods("GET")
 	r.HandleFunc("/api/common/guest-login", c.HandleGuestLogin).Methods("POST")
 	r.HandleFunc("/api/common/oauth-login", c.HandleOauthLogin).Methods("POST")
 
@@ -168,4 +168,35 @@ async function deletet(url) {
     })
     .then(r => r.text())
     .catch(e => { showError(e); throw e; });
-}
\ No newline at end of file
+}
+
+// Shows the time left in elem, counting down from remainingMs.
+// onDone is called when the countdown runs out. Passing a falsy remainingMs hides the countdown.
+var countdownTimer;
+function showCountdown(elem, remainingMs, onDone) {
+  window.clearInterval(countdownTimer);
+  if (!remainingMs) {
+    elem.textContent = '';
+    return;
+  }
+  // The server tells us how much time is left, rather than when the deadline is,
+  // so that this works even if the local clock is off.
+  const deadline = Date.now() + remainingMs;
+  const tick = () => {
+    const left = Math.max(0, Math.ceil((deadline - Date.now()) / 1000));
+    const mmss = Math.floor(left / 60 % 60) + ':' + String(left % 60).padStart(2, '0');
+    if (left >= 3600) {
+      elem.textContent = Math.floor(left / 3600) + ':' + mmss.padStart(5, '0');
+    } else {
+      elem.textContent = mmss;
+    }
+    if (left == 0) {
+      window.clearInterval(countdownTimer);
+      if (onDone) {
+        onDone();
+      }
+    }
+  };
+  tick();
+  countdownTimer = window.setInterval(tick, 250);
+}
@@ -18,7 +18,9 @@ function participantSubmitAnswer(e) {
   e.preventDefault();
   const formElement = document.getElementById('ansform');
   const checkElement = document.querySelector('.donecheck');
-  const data = new URLSearchParams(new FormData(formElement));
+  // Files can only be sent as a multipart form.
+  const data = formElement.querySelector('input[type="file"]') ?
+      new FormData(formElement) : new URLSearchParams(new FormData(formElement));
 
   postj('/api/participant/submit-answer', data)
     .then(j => {
@@ -34,22 +36,117 @@ function participantSubmitAnswer(e) {
     });
 }
 
-async function updateOnStatus() {
-  const qzid = parseInt(document.getElementById('qz-id').value);
+// Plays the joker on the round chosen in the joker form.
+function playJoker(e) {
+  e.preventDefault();
+  const formElement = document.getElementById('jokerform');
+  const select = document.getElementById('joker-rd-id');
+  const chosen = select.options[select.selectedIndex].text;
+  const data = new URLSearchParams(new FormData(formElement));
+
+  posty('/api/participant/play-joker', data)
+    .then(r => {
+      document.getElementById('joker').innerHTML =
+          '<p>You played your joker on ' + chosen + '. Your points in it count double.</p>';
+    })
+    .catch(showError);
+}
+
+// Moves an item of an ordering question up (delta -1) or down (delta 1) the list.
+// The hidden inputs go with the items, so the form sends them in the new order.
+function moveOrderItem(btn, delta) {
+  const item = btn.closest('li');
+  if (delta < 0 && item.previousElementSibling) {
+    item.parentElement.insertBefore(item, item.previousElementSibling);
+  } else if (delta > 0 && item.nextElementSibling) {
+    item.parentElement.insertBefore(item.nextElementSibling, item);
+  }
+}
+
+// Applies a status update from the server. Returns false if the page is being reloaded.
+function applyQuizStatus(j) {
   const qnid = parseInt(document.getElementById('qn-id').value)
   const sbtn = document.getElementById('submitans');
+  if (j && j.QuestionID && (j.QuestionID != qnid)) {
+    location.reload();
+    return false;
+  }
+  if (j && j.Lobby) {
+    showLobby(j.Lobby);
+    return true;
+  }
+  if (!sbtn) {
+    // The quiz is no longer scheduled, so the lobby page is out of date.
+    location.reload();
+    return false;
+  }
+  const revealed = document.getElementById('revealed').value == 'true';
+  if (j && (!!j.Revealed != revealed)) {
+    // The answer was revealed or hidden, which changes the whole page.
+    location.reload();
+    return false;
+  }
+  if (j && j.hasOwnProperty('AcceptingResponses')) {
+    sbtn.disabled = !j.AcceptingResponses;
+  }
+  showCountdown(document.getElementById('countdown'), j && j.RemainingMs, () => {
+    sbtn.disabled = true;
+  });
+  return true;
+}
+
+// Shows who is waiting in the lobby, and how long until the quiz starts.
+function showLobby(lobby) {
+  const list = document.getElementById('lobby-participants');
+  list.innerHTML = '';
+  for (let name of lobby.Participants || []) {
+    const li = document.createElement('li');
+    li.textContent = name;
+    list.appendChild(li);
+  }
+  const soon = document.getElementById('lobby-starting-soon');
+  soon.style.display = lobby.StartsInMs ? 'none' : 'inline';
+  const countdown = document.getElementById('countdown');
+  showCountdown(countdown, lobby.StartsInMs, () => {
+    countdown.textContent = '';
+    soon.style.display = 'inline';
+  });
+}
+
+// Listens for status changes pushed by the server. Falls back to polling
+// if the browser does not support server-sent events, or the stream fails.
+function listenForStatus() {
+  if (!window.EventSource) {
+    window.setTimeout(updateOnStatus, currentTimeout);
+    return;
+  }
+  const qzid = parseInt(document.getElementById('qz-id').value);
+  const es = new EventSource('/api/participant/quiz/' + qzid + '/statusstream');
+  let opened = false;
+  es.onopen = () => { opened = true; };
+  es.onmessage = e => {
+    if (!applyQuizStatus(JSON.parse(e.data))) {
+      es.close();
+    }
+  };
+  es.onerror = _ => {
+    if (opened && es.readyState != EventSource.CLOSED) {
+      // The browser is reconnecting by itself.
+      return;
+    }
+    es.close();
+    window.setTimeout(updateOnStatus, currentTimeout);
+  };
+}
+
+async function updateOnStatus() {
+  const qzid = parseInt(document.getElementById('qz-id').value);
   const info = document.getElementById('info');
   let success = false;
   await getj('/api/participant/quiz/' + qzid + '/getstatus')
     .then(j => {
       success = true;
-      if (j && j.QuestionID && (j.QuestionID != qnid)) {
-        location.reload();
-        return;
-      }
-      if (j && j.hasOwnProperty('AcceptingResponses')) {
-        sbtn.disabled = !j.AcceptingResponses;
-      }
+      applyQuizStatus(j);
     })
     .catch(_ => { /* error already handled, but we want to continue */});
 
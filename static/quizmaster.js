@@ -33,6 +33,10 @@
     putt('/api/quizmaster/question/' + existingQnId + '/update', data)
       .then(j => {
         info.innerHTML = "Saved.";
+        if (data.get('qn-unlink')) {
+          document.getElementById('qn-bank-linked').style.display = 'none';
+          document.getElementById('qn-unlink').checked = false;
+        }
         const ctr = findQnContainerWithId(existingQnId);
         if (ctr) {
           ctr.querySelector('.question-title').innerHTML = data.get("qn-title");
@@ -95,6 +99,17 @@ function delbtnClick(e) {
     })
 }
 
+// Saves a copy of the question, as it was last saved, to the question bank.
+function btnbankClick(e) {
+  const data = new URLSearchParams({
+    'qn-id': document.getElementById('qn-id').value,
+    'bank-tags': document.getElementById('bank-tags').value,
+  });
+  posty('/api/quizmaster/bank/save', data)
+    .then(r => { document.getElementById('info').innerHTML = "Saved to the question bank."; })
+    .catch(showError);
+}
+
 function populateQuestionForm(j) {
   /*
   Note: j is of the form
@@ -109,6 +124,63 @@ function populateQuestionForm(j) {
   document.getElementById('qn-title').dispatchEvent(e);
   document.getElementById('qn-body').value = j.htmlBody;
   document.getElementById('qn-body').dispatchEvent(e);
+  document.getElementById('qn-time-limit').value = j.timeLimitS || '';
+  document.getElementById('qn-time-limit').dispatchEvent(e);
+  document.getElementById('qn-solution').value = j.htmlSolution || '';
+  document.getElementById('qn-solution').dispatchEvent(e);
+  // Note that protojson sends int64 values as strings.
+  const key = j.answerKey || {};
+  document.getElementById('qn-points').value = j.points || '';
+  document.getElementById('qn-wager').checked = !!j.wager;
+  document.getElementById('qn-tie-breaker').checked = !!j.tieBreaker;
+  document.getElementById('qn-round').value = j.roundId || '';
+  document.getElementById('qn-bank-linked').style.display = j.linkedToBank ? 'block' : 'none';
+  document.getElementById('qn-unlink').checked = false;
+  document.getElementById('qn-bank-save').style.display = 'block';
+  const scale = j.pointScale || {};
+  document.getElementById('qn-scale-presets').value = (scale.presets || []).join(', ');
+  document.getElementById('qn-scale-wrong').value = scale.hasOwnProperty('wrongAnswerPoints') ? scale.wrongAnswerPoints : '';
+  document.getElementById('qn-scale-min').value = scale.hasOwnProperty('minPoints') ? scale.minPoints : '';
+  document.getElementById('qn-scale-max').value = scale.hasOwnProperty('maxPoints') ? scale.maxPoints : '';
+  document.getElementById('qn-key-text').value = (key.acceptedTexts || []).join('\n');
+  document.getElementById('qn-key-aliases').value = (key.aliases || []).join('\n');
+  document.getElementById('qn-key-max-edits').value = key.maxEditDistance || '';
+  fillTextNormalization(key.normalization || {});
+  document.getElementById('qn-key-int').value = key.correctInt || '';
+  document.getElementById('qn-key-float').value = key.hasOwnProperty('correctFloat') ? key.correctFloat : '';
+  document.getElementById('qn-key-min').value = key.hasOwnProperty('rangeMin') ? key.rangeMin : '';
+  document.getElementById('qn-key-max').value = key.hasOwnProperty('rangeMax') ? key.rangeMax : '';
+  removeAllBandRows();
+  for (const b of key.toleranceBands || []) {
+    const pct = b.hasOwnProperty('pointsPercent') ? b.pointsPercent : 100;
+    if (b.hasOwnProperty('absolute')) {
+      addBandRow(b.absolute, 'abs', pct);
+    } else {
+      addBandRow(b.relativePercent, 'pct', pct);
+    }
+  }
+  document.getElementById('qn-key-bool').value = key.hasOwnProperty('correctBool') ? String(key.correctBool) : '';
+  document.getElementById('qn-key-choice').value =
+      key.hasOwnProperty('correctChoiceIndex') ? parseInt(key.correctChoiceIndex) + 1 : '';
+  document.getElementById('qn-key-choices').value =
+      (key.correctChoiceIndices || []).map(i => parseInt(i) + 1).join(', ');
+  document.getElementById('qn-key-msq-grading').value = {
+    'PER_CORRECT_OPTION': 'per-option',
+    'PENALTY_PER_WRONG_OPTION': 'penalty',
+  }[key.multiSelectGrading] || 'all';
+  document.getElementById('qn-key-order').value =
+      (key.correctOrder || []).map(i => parseInt(i) + 1).join(', ');
+  document.getElementById('qn-key-order-grading').value = {
+    'POSITIONS_CORRECT': 'positions',
+    'KENDALL_TAU': 'kendall',
+  }[key.orderingGrading] || 'exact';
+  removeAllBlankRows();
+  for (const b of key.blanks || []) {
+    addBlankRow((b.acceptedTexts || []).join(' | '), b.hasOwnProperty('points') ? b.points : '');
+  }
+  document.getElementById('qn-key-pairs').value = (key.correctPairs || [])
+      .map(pr => (parseInt(pr.left || 0) + 1) + '-' + String.fromCharCode(65 + parseInt(pr.right || 0)))
+      .join(', ');
 
   let tp = document.getElementById('qn-new-type-text');
   switch (j.type) {
@@ -130,6 +202,21 @@ function populateQuestionForm(j) {
     case "MULTIPLE_CHOICE_ANSWER":
       tp = document.getElementById('qn-new-type-mcq');
       break;
+    case "MULTI_SELECT_ANSWER":
+      tp = document.getElementById('qn-new-type-msq');
+      break;
+    case "ORDERING_ANSWER":
+      tp = document.getElementById('qn-new-type-order');
+      break;
+    case "MATCHING_ANSWER":
+      tp = document.getElementById('qn-new-type-match');
+      break;
+    case "CLOZE_ANSWER":
+      tp = document.getElementById('qn-new-type-cloze');
+      break;
+    case "UPLOAD_ANSWER":
+      tp = document.getElementById('qn-new-type-upload');
+      break;
     default:
       document.getElementById('info').innerHTML = "Got a weird question type";
       break;
@@ -138,7 +225,7 @@ function populateQuestionForm(j) {
   tp.dispatchEvent(e);
 
   removeAllMcqRows();
-  if (j.choices && j.type == "MULTIPLE_CHOICE_ANSWER") {
+  if (j.choices && ["MULTIPLE_CHOICE_ANSWER", "MULTI_SELECT_ANSWER", "ORDERING_ANSWER", "MATCHING_ANSWER"].includes(j.type)) {
     for (let i = 0; i < j.choices.length; i++) {
       if (i == 0) {
         document.getElementById('mcq-inp-1').value = j.choices[0].htmlBody;
@@ -147,20 +234,70 @@ function populateQuestionForm(j) {
       }
     }
   }
+  document.getElementById('qn-match-right').value = (j.matchChoices || []).map(ch => ch.htmlBody).join('\n');
   document.getElementById('btncrt-label').innerHTML = "Update Question";
   document.getElementById('btndel').disabled = false;
 }
 
+// fillTextNormalization checks the boxes for the differences that are ignored between
+// text answers. Fields that are not set keep the defaults in quiz.proto.
+function fillTextNormalization(n) {
+  const checked = {
+    'case': n.hasOwnProperty('ignoreCase') ? n.ignoreCase : true,
+    'whitespace': n.hasOwnProperty('ignoreWhitespace') ? n.ignoreWhitespace : true,
+    'punctuation': !!n.ignorePunctuation,
+    'diacritics': !!n.ignoreDiacritics,
+    'articles': !!n.ignoreArticles,
+  };
+  for (const [k, v] of Object.entries(checked)) {
+    document.getElementById('qn-text-norm-' + k).checked = v;
+  }
+}
+
 function resetForm() {
   document.getElementById('qn-id').value = '';
   document.getElementById('qn-title').value = '';
   document.getElementById('qn-body').value = '';
+  document.getElementById('qn-time-limit').value = '';
+  document.getElementById('qn-solution').value = '';
+  document.getElementById('qn-points').value = '';
+  document.getElementById('qn-wager').checked = false;
+  document.getElementById('qn-tie-breaker').checked = false;
+  document.getElementById('qn-round').value = '';
+  document.getElementById('qn-bank-linked').style.display = 'none';
+  document.getElementById('qn-unlink').checked = false;
+  document.getElementById('qn-bank-save').style.display = 'none';
+  document.getElementById('bank-tags').value = '';
+  for (const f of ['presets', 'wrong', 'min', 'max']) {
+    document.getElementById('qn-scale-' + f).value = '';
+  }
+  document.getElementById('qn-key-text').value = '';
+  document.getElementById('qn-key-aliases').value = '';
+  document.getElementById('qn-key-max-edits').value = '';
+  fillTextNormalization({ignoreCase: true, ignoreWhitespace: true, ignorePunctuation: true,
+    ignoreDiacritics: true, ignoreArticles: true});
+  document.getElementById('qn-key-int').value = '';
+  document.getElementById('qn-key-float').value = '';
+  document.getElementById('qn-key-min').value = '';
+  document.getElementById('qn-key-max').value = '';
+  removeAllBandRows();
+  document.getElementById('qn-key-bool').value = '';
+  document.getElementById('qn-key-choice').value = '';
+  document.getElementById('qn-key-choices').value = '';
+  document.getElementById('qn-key-msq-grading').value = 'all';
+  document.getElementById('qn-key-order').value = '';
+  document.getElementById('qn-key-order-grading').value = 'exact';
+  document.getElementById('qn-key-pairs').value = '';
+  document.getElementById('qn-match-right').value = '';
+  removeAllBlankRows();
   document.getElementById('qn-new-type-text').checked = true;
   removeAllMcqRows();
   qnTypeChanged(document.getElementById('qn-new-type-text'));
   let e = new Event('blur', { bubbles: true, cancelable: true });
   document.getElementById('qn-title').dispatchEvent(e);
   document.getElementById('qn-body').dispatchEvent(e);
+  document.getElementById('qn-time-limit').dispatchEvent(e);
+  document.getElementById('qn-solution').dispatchEvent(e);
   document.getElementById('btndel').disabled = true;
 }
 
@@ -187,6 +324,37 @@ function addMcqRowWithText(txt) {
   ma.appendChild(clone);
 }
 
+// addBandRow adds a tolerance band for numeric answers to the question form.
+function addBandRow(tol, unit, pct) {
+  const template = document.getElementById('band-template');
+  let clone = template.content.cloneNode(true);
+  clone.querySelector('.band-tol').value = tol;
+  clone.querySelector('.band-unit').value = unit;
+  clone.querySelector('.band-points').value = pct;
+  document.getElementById('band-author').appendChild(clone);
+}
+function removeAllBandRows() {
+  document.getElementById('band-author').replaceChildren();
+}
+
+// addBlankRow adds the key to one blank of a fill in the blanks question to the question form.
+function addBlankRow(accept, points) {
+  const template = document.getElementById('blank-template');
+  let clone = template.content.cloneNode(true);
+  clone.querySelector('.blank-accept').value = accept;
+  clone.querySelector('.blank-points').value = points;
+  document.getElementById('blank-author').appendChild(clone);
+  numberBlankRows();
+}
+function numberBlankRows() {
+  const rows = document.getElementById('blank-author').children;
+  for (let i = 0; i < rows.length; i++) {
+    rows[i].querySelector('.blank-number').textContent = 'Blank ' + (i + 1) + ':';
+  }
+}
+function removeAllBlankRows() {
+  document.getElementById('blank-author').replaceChildren();
+}
 function removeAllMcqRows() {
   document.getElementById('mcq-inp-1').value = "";
   const ma = document.getElementById("mcq-author");
@@ -218,13 +386,20 @@ function removerow(e) {
 }
 
 function qnTypeChanged(e) {
-  const mcqOption = document.getElementById('qn-new-type-mcq');
   const authorDiv = document.getElementById('mcq-author-container');
-  if (mcqOption.checked) {
+  const withOptions = ['qn-new-type-mcq', 'qn-new-type-msq', 'qn-new-type-order', 'qn-new-type-match'];
+  const matchDiv = document.getElementById('match-author-container');
+  matchDiv.style.display = document.getElementById('qn-new-type-match').checked ? 'block' : 'none';
+  if (withOptions.some(id => document.getElementById(id).checked)) {
     authorDiv.style.display = 'block';
   } else {
     authorDiv.style.display = 'none';
   }
+  // Only show the answer key that goes with the question type.
+  const qnType = document.querySelector('input[name="qn-type"]:checked').value;
+  for (let k of document.querySelectorAll('.answer-key')) {
+    k.style.display = k.dataset['qntype'].split(' ').includes(qnType) ? 'block' : 'none';
+  }
 }
 
 function findQnContainerWithId(qnid) {
@@ -235,6 +410,60 @@ function findQnContainerWithId(qnid) {
   }
 }
 
+// The question being dragged to a new place in the list, and the order before the drag.
+let draggedQn = null;
+let orderBeforeDrag = '';
+
+function getQuestionOrder() {
+  const ids = [];
+  for (let e of document.querySelectorAll('#qn-pane-parent .question-container[data-qnid]')) {
+    ids.push(e.dataset.qnid);
+  }
+  return ids.join(',');
+}
+
+function qnDragStart(e) {
+  draggedQn = e.target.closest('.question-container[data-qnid]');
+  if (!draggedQn) {
+    return;
+  }
+  orderBeforeDrag = getQuestionOrder();
+  e.dataTransfer.effectAllowed = 'move';
+  draggedQn.classList.add('question-dragging');
+}
+
+// Moves the dragged question above or below the one under the pointer.
+function qnDragOver(e) {
+  const over = e.target.closest('.question-container[data-qnid]');
+  if (!draggedQn || !over) {
+    return;
+  }
+  e.preventDefault();
+  if (over == draggedQn) {
+    return;
+  }
+  const rect = over.getBoundingClientRect();
+  const below = e.clientY > rect.top + rect.height / 2;
+  over.parentNode.insertBefore(draggedQn, below ? over.nextSibling : over);
+}
+
+function qnDragEnd(e) {
+  if (!draggedQn) {
+    return;
+  }
+  draggedQn.classList.remove('question-dragging');
+  draggedQn = null;
+  const order = getQuestionOrder();
+  if (order == orderBeforeDrag) {
+    return;
+  }
+  const qzid = parseInt(document.getElementById('qz-id').value);
+  const data = new URLSearchParams({'qn-ids': order});
+  posty('/api/quizmaster/quiz/' + qzid + '/reorder', data)
+    .then(r => { document.getElementById('info').innerHTML = "Saved the question order."; })
+    .catch(showError);
+}
+
 function switchToQuestionPane() {
   document.getElementById('qzformpane').style.display = 'none';
   document.getElementById('qnformpane').style.display = 'block';
@@ -249,13 +478,55 @@ function quizClicked() {
   switchToQuizPane();
 }
 
+// Shows the scheduled start of the quiz in the local time zone.
+function fillQuizStartTime() {
+  const inp = document.getElementById('qz-start');
+  if (inp.dataset && inp.dataset['timestamp']) {
+    const d = new Date(parseInt(inp.dataset['timestamp']) * 1000);
+    // datetime-local inputs take the local time without a time zone.
+    inp.value = new Date(d.getTime() - d.getTimezoneOffset() * 60000).toISOString().slice(0, 16);
+  }
+}
+
 function btnqzupdateClick(e) {
   const data = new URLSearchParams(new FormData(document.getElementById('qz-form')));
+  const start = document.getElementById('qz-start').value;
+  if (start) {
+    // The browser reads the input as local time, so the server does not need our time zone.
+    data.set('qz-start-time', Math.floor(new Date(start).getTime() / 1000));
+  }
   const qzid = parseInt(document.getElementById('qz-id').value);
   putt('/api/quizmaster/quiz/' + qzid + '/updateproperties', data)
     .then(j => { document.getElementById('info').innerHTML = "Updated Quiz."; })
 }
 
+// Adds or updates the round in the form of the button. The page is reloaded
+// so that the question form offers the new round.
+function saveRound(btn) {
+  const data = new URLSearchParams(new FormData(btn.closest('form')));
+  const qzid = parseInt(document.getElementById('qz-id').value);
+  const rdid = data.get('rd-id');
+  const reload = () => {
+    location.hash = 'rounds';
+    location.reload();
+  };
+  if (rdid) {
+    putt('/api/quizmaster/quiz/' + qzid + '/round/' + rdid + '/update', data).then(reload);
+  } else {
+    posty('/api/quizmaster/quiz/' + qzid + '/round/new', data).then(reload).catch(showError);
+  }
+}
+
+function deleteRound(btn) {
+  const rdid = btn.closest('form').querySelector('input[name="rd-id"]').value;
+  const qzid = parseInt(document.getElementById('qz-id').value);
+  deletet('/api/quizmaster/quiz/' + qzid + '/round/' + rdid + '/delete')
+    .then(t => {
+      location.hash = 'rounds';
+      location.reload();
+    });
+}
+
 function btnqzdelClick(e) {
   const qzid = parseInt(document.getElementById('qz-id').value);
   deletet('/api/quizmaster/quiz/' + qzid + '/delete')
@@ -282,16 +553,31 @@ function btn_nextqClick(e) {
   const curId = parseInt(document.getElementById('qn-id').value);
   const qnIndex = allQuestionIds.indexOf(curId);
   const nextIndex = qnIndex + 1;
+  // The title card of a round comes before its first question.
+  const roundId = roundStarts[allQuestionIds[nextIndex]];
+  if (roundId && roundId != parseInt(document.getElementById('live-round-id').value)) {
+    showRoundCard(roundId);
+    return;
+  }
   sendCurrentQuestion(nextIndex);
 }
 
 function btn_prevqClick(e) {
   const curId = parseInt(document.getElementById('qn-id').value);
   const qnIndex = allQuestionIds.indexOf(curId);
-  const nextIndex = qnIndex - 1;
+  // From a title card, going back shows the question before it again.
+  const fromCard = parseInt(document.getElementById('live-round-id').value) != 0;
+  const nextIndex = fromCard ? qnIndex : qnIndex - 1;
   sendCurrentQuestion(nextIndex);
 }
 
+function showRoundCard(roundId) {
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  posty('/api/quizmaster/quiz/' + qzId + '/showround/' + roundId, new URLSearchParams({}))
+    .then(r => { location.reload(); })
+    .catch(showError);
+}
+
 function sendCurrentQuestion(nextIndex) {
   const qzId = parseInt(document.getElementById('qz-id').value);
   const info = document.getElementById('info');
@@ -313,7 +599,8 @@ async function btn_refreshansClick(e) {
   const curId = parseInt(document.getElementById('qn-id').value);
   const info = document.getElementById('info');
   let success = false;
-  await getty('/api/quizmaster/question/' + curId + '/getallanswers')
+  const grouped = answersGrouped() ? '?grouped=1' : '';
+  await getty('/api/quizmaster/question/' + curId + '/getallanswers' + grouped)
     .then(r => { return r.text(); })
     .then(myText => {
       document.getElementById('answercontainer').innerHTML = myText;
@@ -332,8 +619,123 @@ async function btn_refreshansClick(e) {
       document.getElementById('info').textContent = 'Something\'s wrong. Can\'t seem to fetch answers from the server';
     }
   }
-  if (keepRefreshingScores) {
+  if (keepRefreshingScores && !answerFeed) {
+    window.setTimeout(btn_refreshansClick, currentTimeout, {});
+  }
+}
+
+// The open WebSocket to the server's answer feed, if any. While it is open,
+// answers are pushed to this page and the answer list is not polled.
+var answerFeed;
+
+function connectAnswerFeed() {
+  if (!window.WebSocket) {
     window.setTimeout(btn_refreshansClick, currentTimeout, {});
+    return;
+  }
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  const scheme = (location.protocol == 'https:') ? 'wss://' : 'ws://';
+  const ws = new WebSocket(scheme + location.host + '/api/quizmaster/quiz/' + qzId + '/answerfeed');
+  ws.onopen = () => {
+    answerFeed = ws;
+    // Catch up on the answers that came in before the feed was connected.
+    btn_refreshansClick({});
+  };
+  ws.onmessage = e => { answerFeedMessage(JSON.parse(e.data)); };
+  ws.onclose = () => {
+    const wasOpen = (answerFeed == ws);
+    answerFeed = null;
+    if (keepRefreshingScores) {
+      window.setTimeout(btn_refreshansClick, currentTimeout, {});
+    }
+    if (wasOpen) {
+      // Try to get back on the feed, the polling above covers the gap.
+      window.setTimeout(connectAnswerFeed, 5000);
+    }
+  };
+}
+
+// Whether answers are shown in groups of identical answers, to be graded together.
+function answersGrouped() {
+  const cb = document.getElementById('groupans');
+  return cb && cb.checked;
+}
+
+// The groups are worked out by the server, so they are fetched again when answers
+// or scores change. Bursts of changes are fetched once.
+var groupRefreshTimer;
+function refreshAnswerGroups() {
+  window.clearTimeout(groupRefreshTimer);
+  groupRefreshTimer = window.setTimeout(btn_refreshansClick, 500, {});
+}
+
+function answerFeedMessage(m) {
+  const curId = parseInt(document.getElementById('qn-id').value);
+  const info = document.getElementById('info');
+  if (answersGrouped() && (m.Type == 'answer' || m.Type == 'scores')) {
+    if (m.QuestionID == curId) {
+      refreshAnswerGroups();
+    }
+    return;
+  }
+  switch (m.Type) {
+    case 'answer':
+      if (m.QuestionID == curId && m.Html) {
+        upsertAnswerCard(m.Answer.AnswerID, m.Html);
+      }
+      break;
+    case 'scores':
+      if (m.QuestionID == curId) {
+        for (const ansId in m.Scores) {
+          // Leave alone the scores the quizmaster is still working on.
+          if (!dirtyScores.has(ansId)) {
+            showSavedScore(ansId, m.Scores[ansId]);
+          }
+        }
+      }
+      break;
+    case 'saved':
+      info.innerHTML = "Scores Saved.";
+      break;
+    case 'error':
+      info.textContent = m.Error;
+      break;
+  }
+}
+
+// Adds the answer card to the page, or if it is already present, updates
+// the answer text and time without touching the scores being entered.
+function upsertAnswerCard(ansId, html) {
+  const tmpl = document.createElement('template');
+  tmpl.innerHTML = html.trim();
+  const card = tmpl.content.firstElementChild;
+  const existing = document.getElementById('ans-' + ansId + '-card');
+  if (existing) {
+    existing.querySelector('.anstime').dataset['timestamp'] = card.querySelector('.anstime').dataset['timestamp'];
+    existing.querySelector('.anscontent').textContent = card.querySelector('.anscontent').textContent;
+  } else {
+    let grid = document.getElementById('answergrid');
+    if (!grid) {
+      grid = document.createElement('div');
+      grid.className = 'mdc-layout-grid__inner';
+      grid.id = 'answergrid';
+      document.getElementById('answercontainer').appendChild(grid);
+    }
+    grid.appendChild(card);
+  }
+  qmAnsTimestampReplace();
+}
+
+function showSavedScore(ansId, points) {
+  const radio = document.getElementById('ans-' + ansId + '-score-' + points);
+  if (radio) {
+    radio.checked = true;
+    return;
+  }
+  const custom = document.getElementById('ans-' + ansId + '-score-custom');
+  if (custom) {
+    custom.checked = true;
+    document.getElementById('ans-' + ansId + '-custom-score').value = points;
   }
 }
 
@@ -342,10 +744,61 @@ function btn_btnscoreClick(e) {
   const info = document.getElementById('info');
   const formElement = document.getElementById('scoringform');
   const data = new URLSearchParams(new FormData(formElement));
+  dirtyScores.clear();
+  // Groups are saved by the server, which gives the points to every answer in the group.
+  if (answerFeed && !answersGrouped()) {
+    answerFeed.send(JSON.stringify({ Type: 'scores', QuestionID: curId, Scores: getScoresFromForm(data) }));
+    return;
+  }
   posty('/api/quizmaster/question/' + curId + '/savescores', data)
     .then(r => { return r.text(); })
     .then(t => {
       info.innerHTML = "Scores Saved.";
+      if (answersGrouped()) {
+        savedScores = null;
+        btn_refreshansClick({});
+      }
+    })
+    .catch(showError);
+}
+
+// Follows the countdown on the live question, and notices when it closes responses.
+function qmListenForStatus() {
+  if (!window.EventSource) {
+    return;
+  }
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  const es = new EventSource('/api/participant/quiz/' + qzId + '/statusstream');
+  es.onmessage = e => {
+    const j = JSON.parse(e.data);
+    showCountdown(document.getElementById('countdown'), j.RemainingMs);
+    if (!j.AcceptingResponses) {
+      showResponsesStopped();
+    }
+  };
+}
+
+function showResponsesStopped() {
+  document.getElementById('stopanslabel').innerHTML = "Accept Responses Again";
+  keepRefreshingScores = false;
+}
+
+// Shows or hides the solution to the live question on the participants' screens.
+function btn_revealansClick(e) {
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  const btn = document.getElementById('revealans');
+  const reveal = btn.dataset['revealed'] != 'true';
+  const data = new URLSearchParams();
+  data.append("rv", reveal ? "true" : "false");
+
+  posty('/api/quizmaster/quiz/' + qzId + '/reveal', data)
+    .then(r => { return r.text(); })
+    .then(t => {
+      btn.dataset['revealed'] = reveal ? 'true' : 'false';
+      document.getElementById('revealanslabel').innerHTML = reveal ? "Hide Answer" : "Reveal Answer";
+      if (reveal) {
+        showResponsesStopped();
+      }
     })
     .catch(showError);
 }
@@ -371,19 +824,68 @@ function btn_stopansClick(e) {
       if (ar) {
         stopAnsLabel.innerHTML = "Stop Accepting Responses";
         keepRefreshingScores = true;
+        // Accepting responses again hides the answer.
+        document.getElementById('revealans').dataset['revealed'] = 'false';
+        document.getElementById('revealanslabel').innerHTML = "Reveal Answer";
       } else {
-        stopAnsLabel.innerHTML = "Accept Responses Again";
-        keepRefreshingScores = false;
+        showResponsesStopped();
       }
       btn_refreshansClick({});
     })
     .catch(showError);
 }
 
+// Grades the answers again with the current answer keys. A dry run only shows what would change,
+// and has to come first so that the changes are seen before they are applied.
+function regrade(dryRun) {
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  const form = new FormData(document.getElementById('regradeform'));
+  const data = new URLSearchParams();
+  if (form.get('regrade-scope') == 'question') {
+    data.append('qn-id', document.getElementById('qn-id').value);
+  }
+  data.append('include-overridden', form.get('include-overridden') ? 'true' : 'false');
+  data.append('dry-run', dryRun ? 'true' : 'false');
+
+  posty('/api/quizmaster/quiz/' + qzId + '/regrade', data)
+    .then(r => { return r.text(); })
+    .then(t => {
+      document.getElementById('regradecontainer').innerHTML = t;
+      document.getElementById('applyregrade').disabled = !dryRun;
+      if (!dryRun) {
+        savedScores = null;
+        btn_refreshansClick({});
+      }
+    })
+    .catch(showError);
+}
+
+// Mirrors getIDToScoreMapFromPostForm on the server.
+function getScoresFromForm(data) {
+  const scores = {};
+  for (const [k, v] of data) {
+    const m = k.match(/^ans-([0-9]+)-score$/);
+    if (!m) {
+      continue;
+    }
+    const points = (v == 'custom') ? parseInt(data.get('ans-' + m[1] + '-custom-score')) : parseInt(v);
+    if (!isNaN(points)) {
+      scores[m[1]] = points;
+    }
+  }
+  return scores;
+}
+
 var savedScores;
+// The answer IDs whose scores have been changed on this page but not saved yet.
+var dirtyScores = new Set();
 function scoreChanged(e) {
   const formElement = document.getElementById('scoringform');
   savedScores = new FormData(formElement);
+  const m = e.id.match(/^ans-([0-9]+)-/);
+  if (m) {
+    dirtyScores.add(m[1]);
+  }
   if (e.id.endsWith('-score-custom')) {
     const tElem = document.getElementById(e.id.replace('-score-custom', '-custom-score'));
     tElem.focus();
@@ -397,7 +899,8 @@ function repopulateSavedScores() {
   for (let kvpair of savedScores) {
     const k = kvpair[0];
     const v = kvpair[1];
-    // We get two types of keys here: ans-<ans_id>-score, and ans-<ans_id>-custom-score.
+    // We get two types of keys here: ans-<ans_id>-score, and ans-<ans_id>-custom-score,
+    // or grp-<group_id>-score and grp-<group_id>-custom-score for grouped answers.
     if (k.endsWith('-custom-score')) {
       const textElem = document.getElementById(k);
       if (textElem) {
@@ -410,4 +913,79 @@ function repopulateSavedScores() {
       formElem.checked = true;
     }
   }
-}
\ No newline at end of file
+}
+// Keeps the presenter view in step with the live quiz. The question is reloaded when the
+// quizmaster moves on, and the answers are counted as they come in.
+function presentFollowLive() {
+  const qzId = parseInt(document.getElementById('qz-id').value);
+  const qnId = parseInt(document.getElementById('qn-id').value);
+  if (window.EventSource) {
+    const es = new EventSource('/api/participant/quiz/' + qzId + '/statusstream');
+    es.onmessage = e => {
+      const j = JSON.parse(e.data);
+      const revealed = document.getElementById('revealed').value == 'true';
+      const roundId = parseInt(document.getElementById('live-round-id').value);
+      if (j.QuestionID != qnId || !!j.Revealed != revealed || (j.RoundID || 0) != roundId) {
+        es.close();
+        location.reload();
+        return;
+      }
+      if (j.Lobby) {
+        showCountdown(document.getElementById('countdown'), j.Lobby.StartsInMs);
+        return;
+      }
+      showCountdown(document.getElementById('countdown'), j.RemainingMs);
+      const closed = document.getElementById('responsesclosed');
+      if (closed) {
+        closed.style.display = j.AcceptingResponses ? 'none' : 'inline';
+      }
+    };
+  }
+
+  const count = document.getElementById('answercount');
+  if (!count || !window.WebSocket) {
+    return;
+  }
+  // Participants may update their answers, so count the IDs rather than the messages.
+  const answerIds = new Set(count.dataset['answerids'].split(',').filter(id => id));
+  const scheme = (location.protocol == 'https:') ? 'wss://' : 'ws://';
+  const ws = new WebSocket(scheme + location.host + '/api/quizmaster/quiz/' + qzId + '/answerfeed?readonly=true');
+  ws.onmessage = e => {
+    const m = JSON.parse(e.data);
+    if (m.Type == 'answer' && m.QuestionID == qnId) {
+      answerIds.add(String(m.Answer.AnswerID));
+      count.textContent = answerIds.size;
+    }
+  };
+  ws.onclose = () => {
+    // Reloading catches up on the answers that were missed.
+    window.setTimeout(() => { location.reload(); }, 5000);
+  };
+}
+
+// Copies or links the bank question in the form of the button into the quiz.
+function addBankQuestion(btn, qzid, link) {
+  const bankId = btn.closest('form').querySelector('input[name="bank-id"]').value;
+  const data = new URLSearchParams({'quiz-id': qzid, 'link': link});
+  posty('/api/quizmaster/bank/' + bankId + '/addtoquiz', data)
+    .then(r => {
+      document.getElementById('info').innerHTML = link ? "Linked into the quiz." : "Copied into the quiz.";
+      btn.closest('form').classList.add('bank-question-used');
+    })
+    .catch(showError);
+}
+
+function saveBankTags(btn) {
+  const form = btn.closest('form');
+  const bankId = form.querySelector('input[name="bank-id"]').value;
+  const data = new URLSearchParams({'bank-tags': form.querySelector('input[name="bank-tags"]').value});
+  putt('/api/quizmaster/bank/' + bankId + '/tags', data)
+    .then(t => { document.getElementById('info').innerHTML = "Saved the tags."; });
+}
+
+function deleteBankQuestion(btn) {
+  const form = btn.closest('form');
+  const bankId = form.querySelector('input[name="bank-id"]').value;
+  deletet('/api/quizmaster/bank/' + bankId + '/delete')
+    .then(t => { form.remove(); });
+}
@@ -22,6 +22,7 @@ import (
 	"net/url"
 	"os"
 	"path"
+	"strings"
 )
 
 var (
@@ -51,6 +52,19 @@ func (v *View) RenderTemplate(w http.ResponseWriter, nm string, data interface{}
 	}
 }
 
+// RenderTemplateToString renders the named template with the given params into a string.
+// This is useful for sending fragments of a page over a live connection.
+func (v *View) RenderTemplateToString(nm string, data interface{}) (string, error) {
+	if v.tm == nil {
+		return "", fmt.Errorf("templates have not been loaded")
+	}
+	var b strings.Builder
+	if err := v.tm.ExecuteTemplate(&b, nm, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
 // Should500 checks if err is not nil, and if so logs and sends the error
 func Should500(err error, w http.ResponseWriter, msg string) bool {
 	if err != nil {